@@ -0,0 +1,104 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Strategy decides how the remainder of Allocate is distributed.
+type Strategy int
+
+const (
+	// RemainderLast rounds every share, and gives the remainder to the last
+	// share.
+	RemainderLast Strategy = iota
+
+	// LargestRemainder, also known as the Hamilton method, rounds every share
+	// down, and gives the remaining units one at a time to the shares with
+	// the largest fractional parts. Ties go to the earlier share.
+	LargestRemainder
+)
+
+var strategyNames = map[Strategy]string{
+	RemainderLast:    "remainder-last",
+	LargestRemainder: "largest-remainder",
+}
+
+func (s Strategy) String() string {
+	if name, ok := strategyNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// Validate checks if the strategy is known.
+func (s Strategy) Validate() error {
+	if _, ok := strategyNames[s]; !ok {
+		return fmt.Errorf("%w: %d", ErrStrategyInvalid, int(s))
+	}
+
+	return nil
+}
+
+// WithStrategy sets how Allocate distributes the remainder. The rounding mode
+// is ignored for LargestRemainder.
+func WithStrategy(s Strategy) Option {
+	return func(o *options) {
+		o.strategy = s
+		o.set |= optStrategy
+	}
+}
+
+// largestRemainder allocates the units by the ratios, and returns the number
+// of units for each ratio.
+func largestRemainder(units *big.Int, ratios []*big.Int) []*big.Int {
+	total := SumBig(ratios)
+
+	n := len(ratios)
+	res := make([]*big.Int, n)
+	rems := make([]*big.Int, n)
+
+	// Each quota is units * ratio / total. Since the quotas share the same
+	// denominator, the remainders can be compared directly.
+	left := new(big.Int).Set(units)
+	for i, r := range ratios {
+		res[i], rems[i] = new(big.Int).DivMod(mulBigInt(units, r), total, new(big.Int))
+		left.Sub(left, res[i])
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return rems[idx[i]].Cmp(rems[idx[j]]) > 0
+	})
+
+	for i := 0; left.Sign() > 0; i++ {
+		res[idx[i]].Add(res[idx[i]], one)
+		left.Sub(left, one)
+	}
+
+	return res
+}
+
+func allocateLargestRemainder[T constraints.Integer](amount, unit T, ratios []T) []T {
+	bigRatios := make([]*big.Int, len(ratios))
+	for i, r := range ratios {
+		bigRatios[i] = bigIntFromUint64(uint64(r))
+	}
+
+	shares := largestRemainder(bigIntFromUint64(uint64(amount/unit)), bigRatios)
+
+	res := make([]T, len(shares))
+	for i, s := range shares {
+		res[i] = T(s.Uint64()) * unit
+	}
+
+	return res
+}
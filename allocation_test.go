@@ -0,0 +1,78 @@
+package money_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLargestRemainder() {
+	m := money.NewMoney(5030, 1)
+	a := m.Allocate([]int{1, 2, 5}, money.WithStrategy(money.LargestRemainder))
+
+	fmt.Println(a, money.Sum(a))
+	// Output: [629 1257 3144] 5030
+}
+
+func TestLargestRemainder(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		unit     int64
+		allocate []int64
+		expected []int64
+		scenario string
+	}{
+		{amount: 100, unit: 1, allocate: []int64{1, 1, 1}, expected: []int64{34, 33, 33}, scenario: "ties go to the earlier share"},
+		{amount: 100, unit: 5, allocate: []int64{1, 1, 1}, expected: []int64{35, 35, 30}, scenario: "allocate by 3 equally, unit 5"},
+		{amount: 100, unit: 1, allocate: []int64{1, 2, 3}, expected: []int64{17, 33, 50}, scenario: "allocate by 3 in ratio 1:2:3, unit 1"},
+		{amount: 100, unit: 1, allocate: []int64{0, 1, 0}, expected: []int64{0, 100, 0}, scenario: "allocate by 3 in ratio 0:1:0, unit 1"},
+		{amount: 5030, unit: 5, allocate: []int64{1, 2, 5}, expected: []int64{630, 1255, 3145}, scenario: "allocate by 3 in ratio 1:2:5, unit 5"},
+		{amount: 7, unit: 1, allocate: []int64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, expected: []int64{1, 1, 1, 1, 1, 1, 1, 0, 0, 0}, scenario: "fewer units than ratios"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, test.unit)
+			res := m.Allocate(test.allocate, money.WithStrategy(money.LargestRemainder))
+			assert.Equal(test.expected, res)
+			assert.Equal(test.amount, money.Sum(res))
+
+			ratios := make([]uint64, len(test.allocate))
+			for i, r := range test.allocate {
+				ratios[i] = uint64(r)
+			}
+
+			b := money.NewBigMoney(big.NewInt(test.amount), big.NewInt(test.unit))
+			bres := b.Allocate(ratios, money.WithStrategy(money.LargestRemainder))
+			for i, exp := range test.expected {
+				assert.Equal(exp, bres[i].Int64())
+			}
+		})
+	}
+}
+
+func TestLargestRemainderMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.NewMoney(100, 1)
+	res := money.AllocateMap(m, map[string]int{
+		"a": 1,
+		"b": 1,
+		"c": 1,
+	}, money.WithStrategy(money.LargestRemainder))
+
+	assert.Equal(map[string]int{"a": 34, "b": 33, "c": 33}, res)
+}
+
+func TestStrategyInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := money.NewMoney(100, 1).TryAllocate([]int{1}, money.WithStrategy(money.Strategy(100)))
+	assert.True(errors.Is(err, money.ErrStrategyInvalid))
+}
@@ -11,12 +11,14 @@ var (
 )
 
 type BigMoney struct {
-	amount *big.Int
-	unit   *big.Int
+	amount   *big.Int
+	unit     *big.Int
+	currency Currency
+	signed   bool
 }
 
 func (m *BigMoney) Validate() error {
-	if isLt(m.amount, zero) {
+	if isLt(m.amount, zero) && !m.signed {
 		return ErrNegativeAmount
 	}
 
@@ -40,6 +42,16 @@ func NewBigMoney(amount, unit *big.Int) *BigMoney {
 	}
 }
 
+// NewBigMoneyWithCurrency returns BigMoney in the currency, using the
+// currency's default smallest unit.
+func NewBigMoneyWithCurrency(amount *big.Int, currency Currency) *BigMoney {
+	return &BigMoney{
+		amount:   amount,
+		unit:     big.NewInt(currency.Unit),
+		currency: currency,
+	}
+}
+
 func (m *BigMoney) Amount() *big.Int {
 	return new(big.Int).Set(m.amount)
 }
@@ -48,49 +60,181 @@ func (m *BigMoney) Unit() *big.Int {
 	return new(big.Int).Set(m.unit)
 }
 
-func (m *BigMoney) Split(n uint) []*big.Int {
-	if err := m.Validate(); err != nil {
+func (m *BigMoney) Currency() Currency {
+	return m.currency
+}
+
+// WithAmount returns a new BigMoney of the given amount, carrying over the
+// unit, currency and signed mode.
+func (m *BigMoney) WithAmount(amount *big.Int) *BigMoney {
+	return &BigMoney{
+		amount:   amount,
+		unit:     m.Unit(),
+		currency: m.currency,
+		signed:   m.signed,
+	}
+}
+
+// WithAmounts is like WithAmount, but for the results of Split and Allocate.
+func (m *BigMoney) WithAmounts(amounts []*big.Int) []*BigMoney {
+	res := make([]*BigMoney, len(amounts))
+	for i, amt := range amounts {
+		res[i] = m.WithAmount(amt)
+	}
+
+	return res
+}
+
+// Split divides the amount into n parts, each rounded down to the unit unless
+// WithRounding is given, with the remainder going to the last part. See
+// WithSpread for spreading the remainder instead. It panics if the BigMoney
+// is invalid, see TrySplit.
+func (m *BigMoney) Split(n uint, opts ...Option) []*big.Int {
+	res, err := m.TrySplit(n, opts...)
+	if err != nil {
 		panic(err)
 	}
 
+	return res
+}
+
+// TrySplit is like Split, but returns an error instead of panicking.
+func (m *BigMoney) TrySplit(n uint, opts ...Option) ([]*big.Int, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundFloor, optRounding|optSpread, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkParts(n); err != nil {
+		return nil, err
+	}
+
 	if n == 0 {
-		return make([]*big.Int, 0)
+		return make([]*big.Int, 0), nil
+	}
+
+	if m.amount.Sign() < 0 {
+		return m.mirror(func(abs *BigMoney) ([]*big.Int, error) {
+			return abs.TrySplit(n, opts...)
+		})
+	}
+
+	if o.spread != SpreadNone {
+		return m.splitSpread(n, o), nil
 	}
 
 	nRat64 := new(big.Rat).SetInt64(int64(n))
 	units := bigRatFromBigInt(m.amount, m.unit)
 
-	per := floor(divBigRat(units, nRat64))
+	per := mulBigInt(round(divBigRat(units, nRat64), o.rounding), m.unit)
 	res := make([]*big.Int, n)
 
 	total := m.Amount()
 	for i := 0; i < int(n)-1; i++ {
-		i64 := mulBigInt(per, m.unit)
+		i64 := minBigInt(per, total)
 		res[i] = i64
 		total.Sub(total, i64)
 	}
 
+	if isLt(total, zero) {
+		return nil, fmt.Errorf("%w: %d by %d", ErrInvalidSplit, m.amount, n)
+	}
+
 	res[len(res)-1] = total
 
+	return res, nil
+}
+
+func (m *BigMoney) splitSpread(n uint, o options) []*big.Int {
+	per, left := new(big.Int).DivMod(
+		new(big.Int).Quo(m.amount, m.unit),
+		bigIntFromUint64(uint64(n)),
+		new(big.Int),
+	)
+	per.Mul(per, m.unit)
+	extra := spreadExtra(int(n), int(left.Int64()), o)
+
+	res := make([]*big.Int, n)
+	for i := range res {
+		res[i] = new(big.Int).Set(per)
+		if extra(i) {
+			res[i].Add(res[i], m.unit)
+		}
+	}
+
 	return res
 }
 
-func (m *BigMoney) Allocate(ratios []uint64) []*big.Int {
-	if err := m.Validate(); err != nil {
+// Allocate divides the amount by the ratios, each rounded down to the unit
+// unless WithRounding is given, with the remainder going to the last ratio.
+// See WithStrategy for other ways of distributing the remainder. It panics if
+// the BigMoney is invalid, see TryAllocate.
+func (m *BigMoney) Allocate(ratios []uint64, opts ...Option) []*big.Int {
+	res, err := m.TryAllocate(ratios, opts...)
+	if err != nil {
 		panic(err)
 	}
 
+	return res
+}
+
+// TryAllocate is like Allocate, but returns an error instead of panicking.
+func (m *BigMoney) TryAllocate(ratios []uint64, opts ...Option) ([]*big.Int, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundFloor, optRounding|optStrategy, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(ratios) == 0 {
-		return make([]*big.Int, 0)
+		return make([]*big.Int, 0), nil
 	}
 
 	// Find the total ratio first.
-	var ratio uint64
+	ratio := new(big.Int)
 	for _, r := range ratios {
-		ratio += r
+		ratio.Add(ratio, bigIntFromUint64(r))
+	}
+
+	// All-zero ratios leave the whole amount to the last share.
+	if isEq(ratio, zero) {
+		res := make([]*big.Int, len(ratios))
+		for i := range res {
+			res[i] = big.NewInt(0)
+		}
+		res[len(res)-1] = m.Amount()
+
+		return res, nil
+	}
+
+	if m.amount.Sign() < 0 {
+		return m.mirror(func(abs *BigMoney) ([]*big.Int, error) {
+			return abs.TryAllocate(ratios, opts...)
+		})
 	}
 
-	ratioInt64 := bigRatFromUint64(ratio)
+	if o.strategy == LargestRemainder {
+		bigRatios := make([]*big.Int, len(ratios))
+		for i, r := range ratios {
+			bigRatios[i] = bigIntFromUint64(r)
+		}
+
+		res := largestRemainder(new(big.Int).Quo(m.amount, m.unit), bigRatios)
+		for _, r := range res {
+			r.Mul(r, m.unit)
+		}
+
+		return res, nil
+	}
+
+	ratioInt64 := new(big.Rat).SetInt(ratio)
 	unitsInt64 := bigRatFromBigInt(m.amount, m.unit)
 
 	n := len(ratios)
@@ -109,37 +253,141 @@ func (m *BigMoney) Allocate(ratios []uint64) []*big.Int {
 		// shares = shares * (amount / unit)
 		r64.Mul(r64, unitsInt64)
 
-		// shares = floor(shares)
-		i64 := floor(r64)
+		// shares = round(shares)
+		i64 := round(r64, o.rounding)
 
 		// shares = shares * m.unit
-		i64 = mulBigInt(i64, m.unit)
+		i64 = minBigInt(mulBigInt(i64, m.unit), total)
 
 		res[i] = i64
 		total.Sub(total, i64)
 	}
 
+	if isLt(total, zero) {
+		return nil, fmt.Errorf("%w: %d by %v", ErrInvalidAllocation, m.amount, ratios)
+	}
+
 	res[n-1] = total
 
+	return res, nil
+}
+
+// Discount returns the discounted amount, see Money.Discount. It panics if
+// the BigMoney or percent is invalid, see TryDiscount.
+func (m *BigMoney) Discount(percent Percent, opts ...Option) *big.Int {
+	return m.DiscountPercentage(percent, opts...)
+}
+
+// TryDiscount is like Discount, but returns an error instead of panicking.
+func (m *BigMoney) TryDiscount(percent Percent, opts ...Option) (*big.Int, error) {
+	return m.TryDiscountPercentage(percent, opts...)
+}
+
+// DiscountPercentage returns the discounted amount, see
+// Money.DiscountPercentage. It panics if the BigMoney or percent is invalid,
+// see TryDiscountPercentage.
+func (m *BigMoney) DiscountPercentage(percent Percenter, opts ...Option) *big.Int {
+	res, err := m.TryDiscountPercentage(percent, opts...)
+	if err != nil {
+		panic(err)
+	}
+
 	return res
 }
 
-func (m *BigMoney) Discount(percent Percent) *big.Int {
+// TryDiscountPercentage is like DiscountPercentage, but returns an error
+// instead of panicking.
+func (m *BigMoney) TryDiscountPercentage(percent Percenter, opts ...Option) (*big.Int, error) {
 	if err := m.Validate(); err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	if err := percent.Validate(); err != nil {
-		panic(err)
+	return discount(m.amount, m.unit, percent, opts)
+}
+
+// Add returns the sum of both amounts.
+func (m *BigMoney) Add(o *BigMoney) (*BigMoney, error) {
+	if err := m.compatible(o); err != nil {
+		return nil, err
 	}
 
-	units := bigRatFromBigInt(m.amount, m.unit)
+	return m.WithAmount(new(big.Int).Add(m.amount, o.amount)), nil
+}
+
+// Sub returns the difference of both amounts.
+func (m *BigMoney) Sub(o *BigMoney) (*BigMoney, error) {
+	if err := m.compatible(o); err != nil {
+		return nil, err
+	}
+
+	return m.WithAmount(new(big.Int).Sub(m.amount, o.amount)), nil
+}
 
-	r64 := divBigRatUint64(uint64(percent), 100)
-	r64.Mul(r64, units)
+// MulInt returns the amount multiplied by n.
+func (m *BigMoney) MulInt(n int64) *BigMoney {
+	return m.WithAmount(mulBigInt(m.amount, big.NewInt(n)))
+}
+
+// Neg returns the negated amount. Note that Validate rejects negative
+// amounts unless the BigMoney is Signed.
+func (m *BigMoney) Neg() *BigMoney {
+	return m.WithAmount(new(big.Int).Neg(m.amount))
+}
+
+// Cmp compares both amounts, and returns -1, 0 or +1 like big.Int.Cmp.
+func (m *BigMoney) Cmp(o *BigMoney) (int, error) {
+	if err := m.compatible(o); err != nil {
+		return 0, err
+	}
+
+	return m.amount.Cmp(o.amount), nil
+}
 
-	i64 := ceil(r64)
-	i64.Mul(i64, m.unit)
+// Equal returns true if both have the same amount, unit and currency.
+func (m *BigMoney) Equal(o *BigMoney) bool {
+	return m.compatible(o) == nil && isEq(m.amount, o.amount)
+}
+
+func (m *BigMoney) IsZero() bool {
+	return isEq(m.amount, zero)
+}
+
+// Min returns the smaller of both.
+func (m *BigMoney) Min(o *BigMoney) (*BigMoney, error) {
+	cmp, err := m.Cmp(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmp > 0 {
+		return o.WithAmount(o.Amount()), nil
+	}
 
-	return i64
+	return m.WithAmount(m.Amount()), nil
+}
+
+// Max returns the larger of both.
+func (m *BigMoney) Max(o *BigMoney) (*BigMoney, error) {
+	cmp, err := m.Cmp(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmp < 0 {
+		return o.WithAmount(o.Amount()), nil
+	}
+
+	return m.WithAmount(m.Amount()), nil
+}
+
+func (m *BigMoney) compatible(o *BigMoney) error {
+	if !m.currency.Equal(o.currency) {
+		return fmt.Errorf("%w: %q and %q", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+
+	if !isEq(m.unit, o.unit) {
+		return fmt.Errorf("%w: %d and %d", ErrUnitMismatch, m.unit, o.unit)
+	}
+
+	return nil
 }
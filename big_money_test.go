@@ -134,3 +134,80 @@ func TestBigMoneyDiscount(t *testing.T) {
 		})
 	}
 }
+
+func TestBigMoneyArithmetic(t *testing.T) {
+	usd := func(amount int64) *money.BigMoney {
+		return money.NewBigMoneyWithCurrency(big.NewInt(amount), money.USD)
+	}
+
+	t.Run("add and sub", func(t *testing.T) {
+		assert := assert.New(t)
+
+		a := usd(100)
+		sum, err := a.Add(usd(50))
+		assert.Nil(err)
+		assert.True(sum.Equal(usd(150)))
+		assert.True(a.Equal(usd(100)), "operands are not mutated")
+
+		diff, err := usd(100).Sub(usd(30))
+		assert.Nil(err)
+		assert.True(diff.Equal(usd(70)))
+	})
+
+	t.Run("mul and neg", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.True(usd(25).MulInt(4).Equal(usd(100)))
+		assert.Equal(int64(-25), usd(25).Neg().Amount().Int64())
+		assert.True(usd(0).IsZero())
+	})
+
+	t.Run("cmp, min and max", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cmp, err := usd(2).Cmp(usd(1))
+		assert.Nil(err)
+		assert.Equal(1, cmp)
+
+		lo, err := usd(1).Min(usd(2))
+		assert.Nil(err)
+		assert.True(lo.Equal(usd(1)))
+
+		hi, err := usd(1).Max(usd(2))
+		assert.Nil(err)
+		assert.True(hi.Equal(usd(2)))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := usd(1).Add(money.NewBigMoneyWithCurrency(big.NewInt(1), money.SGD))
+		assert.True(errors.Is(err, money.ErrCurrencyMismatch))
+
+		_, err = usd(1).Sub(money.NewBigMoneyWithCurrency(big.NewInt(1), money.USD.WithUnit(5)))
+		assert.True(errors.Is(err, money.ErrUnitMismatch))
+	})
+}
+
+func TestBigMoneyTry(t *testing.T) {
+	t.Run("invalid money", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewBigMoney(big.NewInt(-1), big.NewInt(1)).TrySplit(3)
+		assert.True(errors.Is(err, money.ErrNegativeAmount))
+
+		_, err = money.NewBigMoney(big.NewInt(3), big.NewInt(2)).TryAllocate([]uint64{1, 1})
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+
+		_, err = money.NewBigMoney(big.NewInt(3), big.NewInt(0)).TryDiscount(money.Percent(5))
+		assert.True(errors.Is(err, money.ErrUnitInvalid))
+	})
+
+	t.Run("invalid percent", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewBigMoney(big.NewInt(100), big.NewInt(1)).TryDiscount(money.Percent(101))
+		assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+	})
+
+}
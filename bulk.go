@@ -0,0 +1,297 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrWeightsChanged = errors.New("money: weights changed between passes")
+
+const defaultBatchSize = 1024
+
+// Payout is the share of a recipient in AllocateBulk.
+type Payout[K any, T constraints.Integer] struct {
+	Recipient K
+	Weight    T
+	Amount    T
+}
+
+// WeightSource streams the recipients and their weights to yield, and stops
+// at the first error yield returns. AllocateBulk reads the source twice, so
+// it must yield the same weights in the same order each time, e.g. by
+// running a query ordered by the recipient in a snapshot.
+type WeightSource[K any, T constraints.Integer] func(ctx context.Context, yield func(recipient K, weight T) error) error
+
+// WithWorkers sets the number of goroutines of AllocateBulk, which defaults
+// to GOMAXPROCS. It only applies to AllocateBulk.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+		o.set |= optWorkers
+	}
+}
+
+// WithBatchSize sets the number of payouts of AllocateBulk that are computed
+// and emitted together, which defaults to 1024. It only applies to
+// AllocateBulk.
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		o.batchSize = n
+		o.set |= optBatchSize
+	}
+}
+
+// AllocateBulk allocates the amount by the weights of the source, for more
+// recipients than fit in memory. The first pass sums up the weights, and the
+// second computes the payouts in batches on a bounded number of goroutines,
+// see WithWorkers and WithBatchSize.
+//
+// Each share is the difference of the cumulative quotas before and after the
+// recipient, each rounded down to the unit unless WithRounding is given. The
+// shares are thus within one unit of the exact quota, and sum up to the
+// amount exactly however the batches are split. WithStrategy and WithSpread
+// do not apply, see Option.
+//
+// emit is called concurrently with the payouts of each batch, in no
+// particular order, and must not retain the slice. If AllocateBulk returns an
+// error, e.g. ErrWeightsChanged or the error of the context, the payouts
+// emitted so far are incomplete and must be discarded.
+func AllocateBulk[K any, T constraints.Integer](ctx context.Context, m *Money[T], source WeightSource[K, T], emit func([]Payout[K, T]) error, opts ...Option) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	o, err := newOptions(RoundFloor, optRounding|optWorkers|optBatchSize, opts)
+	if err != nil {
+		return err
+	}
+
+	if o.workers < 1 {
+		o.workers = runtime.GOMAXPROCS(0)
+	}
+
+	if o.batchSize < 1 {
+		o.batchSize = defaultBatchSize
+	}
+
+	// Negative amounts mirror the absolute amount.
+	amount, err := m.abs()
+	if err != nil {
+		return err
+	}
+
+	var total uint128
+	err = source(ctx, func(_ K, weight T) error {
+		if weight < 0 {
+			return fmt.Errorf("%w: %d", ErrRatioInvalid, weight)
+		}
+
+		total = total.add(uint64(weight))
+
+		return ctx.Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	if total == (uint128{}) {
+		return fmt.Errorf("%w: weights sum up to zero", ErrRatioInvalid)
+	}
+
+	q := newCumulativeQuota(uint64(amount/m.unit), total, o.rounding)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	type batch struct {
+		start   uint128
+		payouts []Payout[K, T]
+	}
+
+	// Batches are recycled, so that the payouts in memory are bounded by the
+	// workers times the batch size.
+	batches := make(chan batch)
+	free := make(chan []Payout[K, T], o.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for b := range batches {
+				c := b.start
+				prev := q.at(c)
+				for i := range b.payouts {
+					c = c.add(uint64(b.payouts[i].Weight))
+					next := q.at(c)
+
+					// The share is at most the amount, which fits in T.
+					share := T(next-prev) * m.unit
+					if m.amount < 0 {
+						share = -share
+					}
+
+					b.payouts[i].Amount = share
+					prev = next
+				}
+
+				if ctx.Err() == nil {
+					if err := emit(b.payouts); err != nil {
+						fail(err)
+					}
+				}
+
+				select {
+				case free <- b.payouts[:0]:
+				default:
+				}
+			}
+		}()
+	}
+
+	next := func() []Payout[K, T] {
+		select {
+		case p := <-free:
+			return p
+		default:
+			return make([]Payout[K, T], 0, o.batchSize)
+		}
+	}
+
+	send := func(b batch) error {
+		select {
+		case batches <- b:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var sum uint128
+	b := batch{payouts: next()}
+	err = source(ctx, func(recipient K, weight T) error {
+		if weight < 0 {
+			return fmt.Errorf("%w: %d", ErrRatioInvalid, weight)
+		}
+
+		sum = sum.add(uint64(weight))
+		if sum.cmp(total) > 0 {
+			return ErrWeightsChanged
+		}
+
+		b.payouts = append(b.payouts, Payout[K, T]{Recipient: recipient, Weight: weight})
+		if len(b.payouts) < o.batchSize {
+			return ctx.Err()
+		}
+
+		if err := send(b); err != nil {
+			return err
+		}
+
+		b = batch{start: sum, payouts: next()}
+
+		return nil
+	})
+
+	if err == nil && len(b.payouts) > 0 {
+		err = send(b)
+	}
+
+	if err == nil && sum != total {
+		err = ErrWeightsChanged
+	}
+
+	close(batches)
+	wg.Wait()
+
+	if err != nil {
+		fail(err)
+	}
+
+	return firstErr
+}
+
+// cumulativeQuota is the units times a cumulative weight over the total
+// weight, rounded with the mode.
+type cumulativeQuota struct {
+	units uint64
+	total uint128
+	mode  RoundingMode
+
+	// totalBig is the total if it does not fit in a uint64.
+	totalBig *big.Int
+}
+
+func newCumulativeQuota(units uint64, total uint128, mode RoundingMode) cumulativeQuota {
+	q := cumulativeQuota{
+		units: units,
+		total: total,
+		mode:  mode,
+	}
+	if total.hi != 0 {
+		q.totalBig = total.big()
+	}
+
+	return q
+}
+
+// at returns the quota of the cumulative weight c, which is at most the
+// total, so that the quota is at most the units.
+func (q cumulativeQuota) at(c uint128) uint64 {
+	if q.totalBig == nil {
+		res, _ := mulDivRound(q.units, c.lo, q.total.lo, q.mode)
+		return res
+	}
+
+	x := new(big.Rat).SetFrac(c.big(), q.totalBig)
+	x.Mul(x, bigRatFromUint64(q.units))
+
+	return round(x, q.mode).Uint64()
+}
+
+// uint128 sums up the weights, which may not fit in a uint64.
+type uint128 struct {
+	hi, lo uint64
+}
+
+func (u uint128) add(n uint64) uint128 {
+	lo, carry := bits.Add64(u.lo, n, 0)
+	return uint128{hi: u.hi + carry, lo: lo}
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi || (u.hi == v.hi && u.lo < v.lo):
+		return -1
+	case u == v:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func (u uint128) big() *big.Int {
+	res := new(big.Int).SetUint64(u.hi)
+	res.Lsh(res, 64)
+
+	return res.Or(res, bigIntFromUint64(u.lo))
+}
@@ -0,0 +1,231 @@
+package money_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceSource streams the weights, with the index as the recipient.
+func sliceSource(weights []int64) money.WeightSource[int, int64] {
+	return func(ctx context.Context, yield func(int, int64) error) error {
+		for i, w := range weights {
+			if err := yield(i, w); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// collect allocates in bulk, and returns the amounts by recipient.
+func collect(m *money.Money[int64], source money.WeightSource[int, int64], opts ...money.Option) ([]int64, error) {
+	var (
+		mu  sync.Mutex
+		res = make(map[int]int64)
+	)
+	err := money.AllocateBulk(context.Background(), m, source, func(payouts []money.Payout[int, int64]) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, p := range payouts {
+			res[p.Recipient] = p.Amount
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]int64, len(res))
+	for i, a := range res {
+		amounts[i] = a
+	}
+
+	return amounts, nil
+}
+
+func TestAllocateBulk(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		unit     int64
+		weights  []int64
+		opts     []money.Option
+		expected []int64
+		scenario string
+	}{
+		{amount: 1000, unit: 1, weights: []int64{1, 1, 1}, expected: []int64{333, 333, 334}, scenario: "remainder"},
+		{amount: 1000, unit: 1, weights: []int64{1, 0, 2}, expected: []int64{333, 0, 667}, scenario: "zero weight"},
+		{amount: 1000, unit: 5, weights: []int64{1, 1, 1}, expected: []int64{330, 335, 335}, scenario: "unit"},
+		{amount: 1000, unit: 1, weights: []int64{1, 1, 1}, opts: []money.Option{money.WithRounding(money.RoundCeiling)}, expected: []int64{334, 333, 333}, scenario: "round up"},
+		{amount: -1000, unit: 1, weights: []int64{1, 1, 1}, expected: []int64{-333, -333, -334}, scenario: "negative"},
+		{amount: 10, unit: 1, weights: []int64{math.MaxInt64, math.MaxInt64, math.MaxInt64}, expected: []int64{3, 3, 4}, scenario: "weights overflow uint64"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, test.unit).Signed()
+
+			res, err := collect(m, sliceSource(test.weights), test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, res)
+		})
+	}
+}
+
+func TestAllocateBulkSharding(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	weights := make([]int64, 10_000)
+	for i := range weights {
+		weights[i] = r.Int63n(1_000_000)
+	}
+
+	m := money.NewMoney[int64](123_456_789_00, 1)
+
+	want, err := collect(m, sliceSource(weights), money.WithWorkers(1), money.WithBatchSize(len(weights)))
+	assert.Nil(err)
+
+	sum, err := money.TrySum(want)
+	assert.Nil(err)
+	assert.Equal(m.Amount(), sum)
+
+	// Each share is within one unit of the exact quota.
+	var total int64
+	for _, w := range weights {
+		total += w
+	}
+	for i, w := range weights {
+		quota := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.Amount()), big.NewInt(w)), big.NewInt(total))
+		diff := new(big.Rat).Sub(quota, new(big.Rat).SetInt64(want[i]))
+		assert.True(diff.Abs(diff).Cmp(big.NewRat(1, 1)) < 0, "%d: %d of %s", i, want[i], quota)
+	}
+
+	for _, batchSize := range []int{1, 7, 1000, 20_000} {
+		got, err := collect(m, sliceSource(weights), money.WithWorkers(8), money.WithBatchSize(batchSize))
+		assert.Nil(err)
+		assert.Equal(want, got, "batch size %d", batchSize)
+	}
+}
+
+func TestAllocateBulkErrors(t *testing.T) {
+	m := money.NewMoney[int64](1000, 1)
+	noop := func([]money.Payout[int, int64]) error { return nil }
+
+	t.Run("zero weights", func(t *testing.T) {
+		err := money.AllocateBulk(context.Background(), m, sliceSource([]int64{0, 0}), noop)
+		assert.True(t, errors.Is(err, money.ErrRatioInvalid))
+	})
+
+	t.Run("negative weight", func(t *testing.T) {
+		err := money.AllocateBulk(context.Background(), m, sliceSource([]int64{1, -1}), noop)
+		assert.True(t, errors.Is(err, money.ErrRatioInvalid))
+	})
+
+	t.Run("weights changed", func(t *testing.T) {
+		for _, second := range [][]int64{{1, 2, 4}, {1, 2, 2}, {1, 2}} {
+			pass := 0
+			source := func(ctx context.Context, yield func(int, int64) error) error {
+				pass++
+				if pass == 1 {
+					return sliceSource([]int64{1, 2, 3})(ctx, yield)
+				}
+
+				return sliceSource(second)(ctx, yield)
+			}
+
+			err := money.AllocateBulk(context.Background(), m, source, noop, money.WithBatchSize(1))
+			assert.True(t, errors.Is(err, money.ErrWeightsChanged), "%v", second)
+		}
+	})
+
+	t.Run("emit", func(t *testing.T) {
+		errEmit := errors.New("emit")
+		err := money.AllocateBulk(context.Background(), m, sliceSource([]int64{1, 2, 3}), func([]money.Payout[int, int64]) error {
+			return errEmit
+		}, money.WithBatchSize(1))
+		assert.True(t, errors.Is(err, errEmit))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		weights := make([]int64, 10_000)
+		for i := range weights {
+			weights[i] = 1
+		}
+
+		var once sync.Once
+		err := money.AllocateBulk(ctx, m, sliceSource(weights), func([]money.Payout[int, int64]) error {
+			once.Do(cancel)
+			return nil
+		}, money.WithBatchSize(10))
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("options that do not apply", func(t *testing.T) {
+		assert := assert.New(t)
+
+		for _, opt := range []money.Option{money.WithStrategy(money.LargestRemainder), money.WithSpread(money.SpreadFirst), money.WithShuffle(1)} {
+			err := money.AllocateBulk(context.Background(), m, sliceSource([]int64{1, 2}), noop, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+		}
+
+		for _, opt := range []money.Option{money.WithWorkers(2), money.WithBatchSize(10)} {
+			_, err := m.TrySplit(3, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = m.TryAllocate([]int64{1, 2}, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = m.Big().TrySplit(3, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = m.Big().TryAllocate([]uint64{1, 2}, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = m.TryDiscount(5, opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = money.TaxExclusive(m, money.NewPercentage(9, 1), opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+			_, err = money.Markup(m, money.NewPercentage(25, 1), opt)
+			assert.True(errors.Is(err, money.ErrOptionInvalid))
+		}
+
+		_, err := m.TryAllocate([]int64{1, 2}, money.WithSpread(money.SpreadFirst))
+		assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+		_, err = m.TrySplit(3, money.WithStrategy(money.LargestRemainder))
+		assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+		_, err = m.Big().TrySplit(3, money.WithUncappedPercent())
+		assert.True(errors.Is(err, money.ErrOptionInvalid))
+
+		rates := money.NewMemoryRates()
+		assert.Nil(rates.Set(money.USD, money.SGD, big.NewRat(135, 100)))
+		_, err = money.Convert(money.USD.New(1, 0), money.SGD, rates, money.WithUncappedPercent())
+		assert.True(errors.Is(err, money.ErrOptionInvalid))
+	})
+
+	t.Run("cancel before", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := money.AllocateBulk(ctx, m, sliceSource([]int64{1}), noop)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
@@ -9,3 +9,12 @@ func isLt(a, b *big.Int) bool {
 func isEq(a, b *big.Int) bool {
 	return 0 == a.Cmp(b)
 }
+
+// Returns a new big.Int set to the smaller of a and b.
+func minBigInt(a, b *big.Int) *big.Int {
+	if isLt(b, a) {
+		return new(big.Int).Set(b)
+	}
+
+	return new(big.Int).Set(a)
+}
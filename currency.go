@@ -0,0 +1,190 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("money: unknown currency")
+	ErrMinorOutOfRange = errors.New("money: minor units out of range")
+)
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	// Code is the three-letter alphabetic code, e.g. USD.
+	Code string
+
+	// Numeric is the three-digit numeric code, e.g. 840 for USD.
+	Numeric int
+
+	// Exponent is the number of digits after the decimal separator, e.g. 2
+	// for USD, where 100 cents make a dollar.
+	Exponent int
+
+	// Unit is the default smallest unit, in minor units, e.g. 5 for a
+	// currency where only 5 cents coins are in circulation.
+	Unit int64
+
+	// Symbol is the commonly used symbol, e.g. $ for USD.
+	Symbol string
+}
+
+var (
+	AED = Currency{Code: "AED", Numeric: 784, Exponent: 2, Unit: 1, Symbol: "AED"}
+	AUD = Currency{Code: "AUD", Numeric: 36, Exponent: 2, Unit: 1, Symbol: "A$"}
+	BHD = Currency{Code: "BHD", Numeric: 48, Exponent: 3, Unit: 1, Symbol: "BHD"}
+	BND = Currency{Code: "BND", Numeric: 96, Exponent: 2, Unit: 1, Symbol: "B$"}
+	BRL = Currency{Code: "BRL", Numeric: 986, Exponent: 2, Unit: 1, Symbol: "R$"}
+	CAD = Currency{Code: "CAD", Numeric: 124, Exponent: 2, Unit: 1, Symbol: "CA$"}
+	CHF = Currency{Code: "CHF", Numeric: 756, Exponent: 2, Unit: 1, Symbol: "CHF"}
+	CLP = Currency{Code: "CLP", Numeric: 152, Exponent: 0, Unit: 1, Symbol: "CLP$"}
+	CNY = Currency{Code: "CNY", Numeric: 156, Exponent: 2, Unit: 1, Symbol: "¥"}
+	CZK = Currency{Code: "CZK", Numeric: 203, Exponent: 2, Unit: 1, Symbol: "Kč"}
+	DKK = Currency{Code: "DKK", Numeric: 208, Exponent: 2, Unit: 1, Symbol: "kr."}
+	EUR = Currency{Code: "EUR", Numeric: 978, Exponent: 2, Unit: 1, Symbol: "€"}
+	GBP = Currency{Code: "GBP", Numeric: 826, Exponent: 2, Unit: 1, Symbol: "£"}
+	HKD = Currency{Code: "HKD", Numeric: 344, Exponent: 2, Unit: 1, Symbol: "HK$"}
+	HUF = Currency{Code: "HUF", Numeric: 348, Exponent: 2, Unit: 1, Symbol: "Ft"}
+	// IDR uses the de facto exponent of 0, since the sen is no longer in
+	// circulation.
+	IDR = Currency{Code: "IDR", Numeric: 360, Exponent: 0, Unit: 1, Symbol: "Rp"}
+	ILS = Currency{Code: "ILS", Numeric: 376, Exponent: 2, Unit: 1, Symbol: "₪"}
+	INR = Currency{Code: "INR", Numeric: 356, Exponent: 2, Unit: 1, Symbol: "₹"}
+	JPY = Currency{Code: "JPY", Numeric: 392, Exponent: 0, Unit: 1, Symbol: "¥"}
+	KRW = Currency{Code: "KRW", Numeric: 410, Exponent: 0, Unit: 1, Symbol: "₩"}
+	KWD = Currency{Code: "KWD", Numeric: 414, Exponent: 3, Unit: 1, Symbol: "KWD"}
+	MXN = Currency{Code: "MXN", Numeric: 484, Exponent: 2, Unit: 1, Symbol: "MX$"}
+	MYR = Currency{Code: "MYR", Numeric: 458, Exponent: 2, Unit: 1, Symbol: "RM"}
+	NOK = Currency{Code: "NOK", Numeric: 578, Exponent: 2, Unit: 1, Symbol: "kr"}
+	NZD = Currency{Code: "NZD", Numeric: 554, Exponent: 2, Unit: 1, Symbol: "NZ$"}
+	PHP = Currency{Code: "PHP", Numeric: 608, Exponent: 2, Unit: 1, Symbol: "₱"}
+	PLN = Currency{Code: "PLN", Numeric: 985, Exponent: 2, Unit: 1, Symbol: "zł"}
+	SAR = Currency{Code: "SAR", Numeric: 682, Exponent: 2, Unit: 1, Symbol: "SAR"}
+	SEK = Currency{Code: "SEK", Numeric: 752, Exponent: 2, Unit: 1, Symbol: "kr"}
+	SGD = Currency{Code: "SGD", Numeric: 702, Exponent: 2, Unit: 1, Symbol: "S$"}
+	THB = Currency{Code: "THB", Numeric: 764, Exponent: 2, Unit: 1, Symbol: "฿"}
+	TRY = Currency{Code: "TRY", Numeric: 949, Exponent: 2, Unit: 1, Symbol: "₺"}
+	TWD = Currency{Code: "TWD", Numeric: 901, Exponent: 2, Unit: 1, Symbol: "NT$"}
+	USD = Currency{Code: "USD", Numeric: 840, Exponent: 2, Unit: 1, Symbol: "$"}
+	VND = Currency{Code: "VND", Numeric: 704, Exponent: 0, Unit: 1, Symbol: "₫"}
+	ZAR = Currency{Code: "ZAR", Numeric: 710, Exponent: 2, Unit: 1, Symbol: "R"}
+)
+
+var currencies = []Currency{
+	AED, AUD, BHD, BND, BRL, CAD, CHF, CLP, CNY, CZK, DKK, EUR, GBP, HKD, HUF,
+	IDR, ILS, INR, JPY, KRW, KWD, MXN, MYR, NOK, NZD, PHP, PLN, SAR, SEK, SGD,
+	THB, TRY, TWD, USD, VND, ZAR,
+}
+
+var (
+	currencyByCode    = make(map[string]Currency, len(currencies))
+	currencyByNumeric = make(map[int]Currency, len(currencies))
+)
+
+func init() {
+	for _, c := range currencies {
+		currencyByCode[c.Code] = c
+		currencyByNumeric[c.Numeric] = c
+	}
+}
+
+// CurrencyByCode returns the registered currency for the alphabetic code.
+// The lookup is case-insensitive.
+func CurrencyByCode(code string) (Currency, error) {
+	c, ok := currencyByCode[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	return c, nil
+}
+
+// CurrencyByNumeric returns the registered currency for the numeric code.
+func CurrencyByNumeric(numeric int) (Currency, error) {
+	c, ok := currencyByNumeric[numeric]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %03d", ErrUnknownCurrency, numeric)
+	}
+
+	return c, nil
+}
+
+// Currencies returns all registered currencies, sorted by code.
+func Currencies() []Currency {
+	res := make([]Currency, len(currencies))
+	copy(res, currencies)
+
+	return res
+}
+
+// IsZero returns true if the currency is not set.
+func (c Currency) IsZero() bool {
+	return c.Code == ""
+}
+
+// Equal returns true if both currencies have the same code.
+func (c Currency) Equal(o Currency) bool {
+	return c.Code == o.Code
+}
+
+func (c Currency) String() string {
+	return c.Code
+}
+
+// WithUnit returns a copy of the currency with a different default smallest
+// unit, e.g. for cash rounding.
+func (c Currency) WithUnit(unit int64) Currency {
+	c.Unit = unit
+	return c
+}
+
+// New returns the amount in major and minor units, e.g. 50 dollars and 30
+// cents, as Money in the smallest unit of the currency. It panics if the
+// minor units are not less than one major unit, or the amount overflows, see
+// TryNew.
+func (c Currency) New(major, minor int64) *Money[int64] {
+	res, err := c.TryNew(major, minor)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// TryNew is like New, but returns an error instead of panicking.
+func (c Currency) TryNew(major, minor int64) (*Money[int64], error) {
+	scale, ok := pow10(c.Exponent)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s exponent %d", ErrOverflow, c, c.Exponent)
+	}
+
+	if minor < 0 || minor >= scale {
+		return nil, fmt.Errorf("%w: %d of %s", ErrMinorOutOfRange, minor, c)
+	}
+
+	amount, overflow := mulOverflows(major, scale)
+	if !overflow {
+		amount, overflow = addOverflows(amount, minor)
+	}
+	if overflow {
+		return nil, fmt.Errorf("%w: %d.%0*d %s", ErrOverflow, major, c.Exponent, minor, c)
+	}
+
+	return NewMoneyWithCurrency(amount, c), nil
+}
+
+// pow10 returns 10 to the power of n, and false if it does not fit in an
+// int64.
+func pow10(n int) (int64, bool) {
+	res := int64(1)
+	for i := 0; i < n; i++ {
+		var overflow bool
+		if res, overflow = mulOverflows(res, 10); overflow {
+			return 0, false
+		}
+	}
+
+	return res, true
+}
@@ -0,0 +1,97 @@
+package money_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrency(t *testing.T) {
+	t.Run("by code", func(t *testing.T) {
+		assert := assert.New(t)
+
+		c, err := money.CurrencyByCode("usd")
+		assert.Nil(err)
+		assert.Equal(money.USD, c)
+		assert.Equal(840, c.Numeric)
+		assert.Equal(2, c.Exponent)
+	})
+
+	t.Run("by numeric", func(t *testing.T) {
+		assert := assert.New(t)
+
+		c, err := money.CurrencyByNumeric(392)
+		assert.Nil(err)
+		assert.Equal(money.JPY, c)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.CurrencyByCode("XYZ")
+		assert.True(errors.Is(err, money.ErrUnknownCurrency))
+
+		_, err = money.CurrencyByNumeric(1)
+		assert.True(errors.Is(err, money.ErrUnknownCurrency))
+	})
+
+	t.Run("new", func(t *testing.T) {
+		assert := assert.New(t)
+
+		usd := money.USD.New(50, 30)
+		assert.Equal(int64(5030), usd.Amount())
+		assert.Equal(int64(1), usd.Unit())
+		assert.Equal(money.USD, usd.Currency())
+
+		sgd := money.SGD.WithUnit(5).New(50, 30)
+		assert.Equal(int64(5030), sgd.Amount())
+		assert.Equal(int64(5), sgd.Unit())
+		assert.True(sgd.Currency().Equal(money.SGD))
+
+		jpy := money.JPY.New(500, 0)
+		assert.Equal(int64(500), jpy.Amount())
+	})
+
+	t.Run("new out of range", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.USD.TryNew(math.MaxInt64/10, 0)
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		_, err = money.USD.TryNew(math.MaxInt64/100, 99)
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		_, err = money.USD.TryNew(50, 100)
+		assert.True(errors.Is(err, money.ErrMinorOutOfRange))
+
+		_, err = money.USD.TryNew(50, -1)
+		assert.True(errors.Is(err, money.ErrMinorOutOfRange))
+
+		_, err = money.JPY.TryNew(500, 1)
+		assert.True(errors.Is(err, money.ErrMinorOutOfRange))
+
+		assert.Panics(func() {
+			money.USD.New(math.MaxInt64/10, 0)
+		})
+	})
+
+	t.Run("carried through", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoneyWithCurrency(100, money.EUR)
+		for _, s := range m.WithAmounts(m.Split(3)) {
+			assert.Equal(money.EUR, s.Currency())
+			assert.Equal(1, s.Unit())
+		}
+
+		b := money.NewBigMoneyWithCurrency(big.NewInt(100), money.EUR)
+		for _, s := range b.WithAmounts(b.Allocate([]uint64{1, 2})) {
+			assert.Equal(money.EUR, s.Currency())
+			assert.Equal(int64(1), s.Unit().Int64())
+		}
+	})
+}
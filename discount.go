@@ -0,0 +1,202 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrDiscountTooLarge = errors.New("money: discount exceeds the line items")
+
+// LineItem is a line of an order that an order-level discount is distributed
+// across.
+type LineItem[T constraints.Integer] struct {
+	Amount *Money[T]
+
+	// MaxDiscount caps the discount of the line, e.g. 0 for items excluded
+	// from promotions. Without a cap, the line is discounted up to its
+	// amount.
+	MaxDiscount *Money[T]
+}
+
+// BigLineItem is like LineItem, but for BigMoney.
+type BigLineItem struct {
+	Amount      *BigMoney
+	MaxDiscount *BigMoney
+}
+
+// DistributeDiscount distributes the order-level discount, e.g. a 10.00
+// coupon, across the lines pro rata to their amounts, see LargestRemainder.
+// Each line's discount is a multiple of the unit and within its cap, and the
+// lines sum up to the discount exactly. What a capped line cannot take is
+// distributed across the other lines.
+func DistributeDiscount[T constraints.Integer](items []LineItem[T], discount *Money[T]) ([]*Money[T], error) {
+	res, err := DistributeDiscountBig(bigLineItems(items), discount.Big())
+	if err != nil {
+		return nil, err
+	}
+
+	return moneysFromBig[T](res)
+}
+
+// DistributePercentDiscount applies the percent discount, e.g. 15% off, to
+// the sum of the lines, and distributes it across the lines, see
+// DistributeDiscount. The order discount is rounded up to the unit unless
+// WithRounding is given.
+func DistributePercentDiscount[T constraints.Integer](items []LineItem[T], percent Percenter, opts ...Option) ([]*Money[T], error) {
+	res, err := DistributePercentDiscountBig(bigLineItems(items), percent, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return moneysFromBig[T](res)
+}
+
+// DistributeDiscountBig is like DistributeDiscount, but for BigMoney.
+func DistributeDiscountBig(items []BigLineItem, discount *BigMoney) ([]*BigMoney, error) {
+	if len(items) == 0 {
+		return nil, ErrNoLines
+	}
+
+	if err := discount.Validate(); err != nil {
+		return nil, err
+	}
+
+	if discount.amount.Sign() < 0 {
+		return nil, ErrNegativeAmount
+	}
+
+	weights := make([]*big.Int, len(items))
+	caps := make([]*big.Int, len(items))
+	for i, item := range items {
+		if err := validateLineItem(item, discount); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+
+		weights[i] = new(big.Int).Quo(item.Amount.amount, discount.unit)
+		caps[i] = new(big.Int).Set(weights[i])
+		if item.MaxDiscount != nil {
+			caps[i] = minBigInt(caps[i], new(big.Int).Quo(item.MaxDiscount.amount, discount.unit))
+		}
+	}
+
+	units := new(big.Int).Quo(discount.amount, discount.unit)
+	if units.Cmp(SumBig(caps)) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDiscountTooLarge, discount)
+	}
+
+	shares := distribute(units, weights, caps)
+
+	res := make([]*BigMoney, len(shares))
+	for i, s := range shares {
+		res[i] = discount.WithAmount(mulBigInt(s, discount.unit))
+	}
+
+	return res, nil
+}
+
+// DistributePercentDiscountBig is like DistributePercentDiscount, but for
+// BigMoney.
+func DistributePercentDiscountBig(items []BigLineItem, percent Percenter, opts ...Option) ([]*BigMoney, error) {
+	if len(items) == 0 {
+		return nil, ErrNoLines
+	}
+
+	total := items[0].Amount.WithAmount(new(big.Int))
+	for i, item := range items {
+		var err error
+		if total, err = total.Add(item.Amount); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+	}
+
+	d, err := total.TryDiscountPercentage(percent, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return DistributeDiscountBig(items, total.WithAmount(d))
+}
+
+func bigLineItems[T constraints.Integer](items []LineItem[T]) []BigLineItem {
+	res := make([]BigLineItem, len(items))
+	for i, item := range items {
+		res[i].Amount = item.Amount.Big()
+		if item.MaxDiscount != nil {
+			res[i].MaxDiscount = item.MaxDiscount.Big()
+		}
+	}
+
+	return res
+}
+
+func validateLineItem(item BigLineItem, discount *BigMoney) error {
+	if err := item.Amount.Validate(); err != nil {
+		return err
+	}
+
+	if item.Amount.amount.Sign() < 0 {
+		return ErrNegativeAmount
+	}
+
+	if err := discount.compatible(item.Amount); err != nil {
+		return err
+	}
+
+	if item.MaxDiscount == nil {
+		return nil
+	}
+
+	if err := item.MaxDiscount.Validate(); err != nil {
+		return err
+	}
+
+	if item.MaxDiscount.amount.Sign() < 0 {
+		return ErrNegativeAmount
+	}
+
+	return discount.compatible(item.MaxDiscount)
+}
+
+// distribute allocates the units pro rata to the weights without exceeding
+// the caps. The units must not exceed the sum of the caps. Whatever the
+// capped shares cannot take is allocated again across the others, until
+// nothing is left.
+func distribute(units *big.Int, weights, caps []*big.Int) []*big.Int {
+	res := make([]*big.Int, len(weights))
+	for i := range res {
+		res[i] = new(big.Int)
+	}
+
+	left := new(big.Int).Set(units)
+	for left.Sign() > 0 {
+		// Only the shares below their caps take part. Since no cap exceeds
+		// its weight, their weights are never all zero. Every round either
+		// allocates everything, or fills at least one share.
+		var idx []int
+		var ws []*big.Int
+		for i, w := range weights {
+			if res[i].Cmp(caps[i]) < 0 {
+				idx = append(idx, i)
+				ws = append(ws, w)
+			}
+		}
+
+		overflow := new(big.Int)
+		for j, s := range largestRemainder(left, ws) {
+			i := idx[j]
+			room := new(big.Int).Sub(caps[i], res[i])
+			if s.Cmp(room) > 0 {
+				overflow.Add(overflow, s.Sub(s, room))
+				s = room
+			}
+			res[i].Add(res[i], s)
+		}
+
+		left = overflow
+	}
+
+	return res
+}
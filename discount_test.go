@@ -0,0 +1,141 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributeDiscount(t *testing.T) {
+	limit := func(n int64) *int64 {
+		return &n
+	}
+
+	tests := []struct {
+		lines    []int64
+		caps     []*int64
+		unit     int64
+		discount int64
+		expected []int64
+		scenario string
+	}{
+		{lines: []int64{1000, 2000, 3000}, unit: 1, discount: 1000, expected: []int64{167, 333, 500}, scenario: "pro rata"},
+		{lines: []int64{500, 5000}, unit: 1, discount: 1000, expected: []int64{91, 909}, scenario: "uncapped"},
+		{lines: []int64{500, 5000}, caps: []*int64{limit(0), nil}, unit: 1, discount: 1000, expected: []int64{0, 1000}, scenario: "excluded line"},
+		{lines: []int64{100, 10000}, caps: []*int64{limit(20), nil}, unit: 1, discount: 10000, expected: []int64{20, 9980}, scenario: "capped line"},
+		{lines: []int64{100, 200}, unit: 1, discount: 300, expected: []int64{100, 200}, scenario: "everything"},
+		{lines: []int64{1000, 1000, 1005}, unit: 5, discount: 100, expected: []int64{35, 30, 35}, scenario: "unit 5"},
+		{lines: []int64{1000, 0}, unit: 1, discount: 0, expected: []int64{0, 0}, scenario: "no discount"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			items := make([]money.LineItem[int64], len(test.lines))
+			for i, l := range test.lines {
+				items[i].Amount = money.NewMoney(l, test.unit)
+				if i < len(test.caps) && test.caps[i] != nil {
+					items[i].MaxDiscount = money.NewMoney(*test.caps[i], test.unit)
+				}
+			}
+
+			res, err := money.DistributeDiscount(items, money.NewMoney(test.discount, test.unit))
+			assert.Nil(err)
+
+			got := make([]int64, len(res))
+			for i, r := range res {
+				got[i] = r.Amount()
+				assert.Equal(test.unit, r.Unit())
+			}
+			assert.Equal(test.expected, got)
+		})
+	}
+}
+
+func TestDistributePercentDiscount(t *testing.T) {
+	assert := assert.New(t)
+
+	items := []money.LineItem[int64]{
+		{Amount: money.NewMoneyWithCurrency[int64](1999, money.USD)},
+		{Amount: money.NewMoneyWithCurrency[int64](2999, money.USD)},
+	}
+
+	res, err := money.DistributePercentDiscount(items, money.NewPercentage(15, 1))
+	assert.Nil(err)
+	assert.Equal(int64(300), res[0].Amount())
+	assert.Equal(int64(450), res[1].Amount())
+	assert.Equal(money.USD, res[0].Currency())
+
+	res, err = money.DistributePercentDiscount(items, money.NewPercentage(15, 1), money.WithRounding(money.RoundFloor))
+	assert.Nil(err)
+	assert.Equal(int64(749), res[0].Amount()+res[1].Amount())
+
+	b, err := money.DistributePercentDiscountBig([]money.BigLineItem{
+		{Amount: money.NewBigMoneyWithCurrency(big.NewInt(1999), money.USD)},
+		{Amount: money.NewBigMoneyWithCurrency(big.NewInt(2999), money.USD)},
+	}, money.NewPercentage(15, 1))
+	assert.Nil(err)
+	assert.Equal(int64(300), b[0].Amount().Int64())
+	assert.Equal(int64(450), b[1].Amount().Int64())
+}
+
+func TestDistributeDiscountErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := money.DistributeDiscount(nil, money.NewMoney[int64](100, 1))
+	assert.True(errors.Is(err, money.ErrNoLines))
+
+	items := []money.LineItem[int64]{{
+		Amount:      money.NewMoney[int64](100, 1),
+		MaxDiscount: money.NewMoney[int64](10, 1),
+	}}
+	_, err = money.DistributeDiscount(items, money.NewMoney[int64](11, 1))
+	assert.True(errors.Is(err, money.ErrDiscountTooLarge))
+
+	_, err = money.DistributeDiscount(items, money.NewMoney[int64](10, 5))
+	assert.True(errors.Is(err, money.ErrUnitMismatch))
+
+	_, err = money.DistributeDiscount(items, money.NewMoneyWithCurrency[int64](10, money.USD))
+	assert.True(errors.Is(err, money.ErrCurrencyMismatch))
+}
+
+func TestDistributeDiscountSum(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		items := make([]money.LineItem[int64], 1+r.Intn(10))
+
+		var capacity int64
+		for i := range items {
+			amount := r.Int63n(10_000)
+			items[i].Amount = money.NewMoney(amount, 1)
+
+			c := amount
+			if r.Intn(3) == 0 {
+				c = r.Int63n(amount + 1)
+				items[i].MaxDiscount = money.NewMoney(c, 1)
+			}
+			capacity += c
+		}
+
+		discount := r.Int63n(capacity + 1)
+		res, err := money.DistributeDiscount(items, money.NewMoney(discount, 1))
+		assert.Nil(err)
+
+		var sum int64
+		for i, d := range res {
+			assert.LessOrEqual(d.Amount(), items[i].Amount.Amount())
+			if items[i].MaxDiscount != nil {
+				assert.LessOrEqual(d.Amount(), items[i].MaxDiscount.Amount())
+			}
+			sum += d.Amount()
+		}
+		assert.Equal(discount, sum)
+	}
+}
@@ -0,0 +1,264 @@
+package money
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrRateNotFound = errors.New("money: exchange rate not found")
+	ErrRateInvalid  = errors.New("money: exchange rate must be positive")
+)
+
+// RateProvider returns the exchange rate for converting one major unit of
+// from into to, e.g. 1.35 for USD to SGD.
+type RateProvider interface {
+	Rate(from, to Currency) (*big.Rat, error)
+}
+
+// Convert converts the amount into the currency, rounded half up to the
+// currency's unit unless WithRounding is given.
+func Convert[T constraints.Integer](m *Money[T], to Currency, p RateProvider, opts ...Option) (*Money[T], error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	amount, err := convert(bigIntFromInteger(m.amount), m.currency, to, p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := integerFromBigInt[T](amount)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOverflow, amount)
+	}
+
+	conv := NewMoneyWithCurrency(res, to)
+	conv.signed = m.signed
+
+	return conv, nil
+}
+
+// ConvertBig is like Convert, but for BigMoney.
+func ConvertBig(m *BigMoney, to Currency, p RateProvider, opts ...Option) (*BigMoney, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	amount, err := convert(m.amount, m.currency, to, p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewBigMoneyWithCurrency(amount, to)
+	res.signed = m.signed
+
+	return res, nil
+}
+
+func convert(amount *big.Int, from, to Currency, p RateProvider, opts []Option) (*big.Int, error) {
+	o, err := newOptions(RoundHalfUp, optRounding, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.Unit < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnitInvalid, to.Unit)
+	}
+
+	rate := big.NewRat(1, 1)
+	if !from.Equal(to) {
+		rate, err = p.Rate(from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		if rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %s to %s at %s", ErrRateInvalid, from, to, rate.RatString())
+		}
+	}
+
+	// The rate is between major units, so scale the minor units by the
+	// difference in exponents.
+	x := new(big.Rat).SetInt(amount)
+	x.Mul(x, rate)
+	x.Mul(x, new(big.Rat).SetFrac(
+		bigPow10(to.Exponent),
+		bigPow10(from.Exponent),
+	))
+
+	unit := big.NewInt(to.Unit)
+	x.Quo(x, new(big.Rat).SetInt(unit))
+
+	return mulBigInt(roundAbs(x, o.rounding), unit), nil
+}
+
+func bigPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// MemoryRates is an in-memory RateProvider. The inverse rate is used when
+// only the opposite pair is set.
+type MemoryRates struct {
+	mu    sync.RWMutex
+	rates map[[2]string]*big.Rat
+}
+
+func NewMemoryRates() *MemoryRates {
+	return &MemoryRates{
+		rates: make(map[[2]string]*big.Rat),
+	}
+}
+
+// Set sets the rate for converting one major unit of from into to.
+func (r *MemoryRates) Set(from, to Currency, rate *big.Rat) error {
+	return r.set(from.Code, to.Code, rate)
+}
+
+func (r *MemoryRates) set(from, to string, rate *big.Rat) error {
+	if rate.Sign() <= 0 {
+		return fmt.Errorf("%w: %s to %s at %s", ErrRateInvalid, from, to, rate.RatString())
+	}
+
+	r.mu.Lock()
+	r.rates[[2]string{from, to}] = new(big.Rat).Set(rate)
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *MemoryRates) Rate(from, to Currency) (*big.Rat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if rate, ok := r.rates[[2]string{from.Code, to.Code}]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+
+	if rate, ok := r.rates[[2]string{to.Code, from.Code}]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s to %s", ErrRateNotFound, from, to)
+}
+
+// LoadRatesFile loads the rates from a CSV file, see ReadRatesCSV, or an
+// ECB-style XML file, see ReadECBRates, depending on the extension.
+func LoadRatesFile(path string) (*MemoryRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return ReadRatesCSV(f)
+	case ".xml":
+		return ReadECBRates(f)
+	default:
+		return nil, fmt.Errorf("money: unknown rates file extension %q", ext)
+	}
+}
+
+// ReadRatesCSV reads rates in the form of from,to,rate, e.g. USD,SGD,1.35.
+// A header row is skipped.
+func ReadRatesCSV(r io.Reader) (*MemoryRates, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+
+	rates := NewMemoryRates()
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rate, ok := new(big.Rat).SetString(rec[2])
+		if !ok {
+			if line == 1 {
+				continue
+			}
+
+			return nil, fmt.Errorf("%w: line %d: %q", ErrSyntax, line, rec[2])
+		}
+
+		if err := rates.set(strings.ToUpper(rec[0]), strings.ToUpper(rec[1]), rate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+type ecbEnvelope struct {
+	Days []ecbDay `xml:"Cube>Cube"`
+}
+
+type ecbDay struct {
+	Time  string    `xml:"time,attr"`
+	Rates []ecbRate `xml:"Cube"`
+}
+
+type ecbRate struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
+
+// ReadECBRates reads the euro foreign exchange reference rates published by
+// the European Central Bank, with EUR as the base. Only the latest day is
+// read.
+func ReadECBRates(r io.Reader) (*MemoryRates, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, err
+	}
+
+	rates := NewMemoryRates()
+	if len(env.Days) == 0 {
+		return rates, nil
+	}
+
+	// The latest day is published first, but pick it by date rather than
+	// trusting the order.
+	var (
+		latest ecbDay
+		at     time.Time
+	)
+	for i, day := range env.Days {
+		t, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrSyntax, day.Time)
+		}
+
+		if i == 0 || t.After(at) {
+			latest, at = day, t
+		}
+	}
+
+	for _, er := range latest.Rates {
+		rate, ok := new(big.Rat).SetString(er.Rate)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s rate %q", ErrSyntax, er.Currency, er.Rate)
+		}
+
+		if err := rates.set(EUR.Code, er.Currency, rate); err != nil {
+			return nil, err
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,190 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const ecbXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-01-03">
+			<Cube currency="USD" rate="1.0919"/>
+			<Cube currency="JPY" rate="155.52"/>
+			<Cube currency="IDR" rate="16982.67"/>
+		</Cube>
+		<Cube time="2024-01-02">
+			<Cube currency="USD" rate="1.0956"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestConvert(t *testing.T) {
+	rates := money.NewMemoryRates()
+	assert.Nil(t, rates.Set(money.USD, money.SGD, big.NewRat(135, 100)))
+	assert.Nil(t, rates.Set(money.USD, money.JPY, big.NewRat(14852, 100)))
+
+	t.Run("direct", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.USD.New(50, 30), money.SGD, rates)
+		assert.Nil(err)
+		assert.True(money.SGD.New(67, 91).Equal(m), m.String()) // 67.905 rounds half up.
+	})
+
+	t.Run("rounding mode", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.USD.New(50, 30), money.SGD, rates, money.WithRounding(money.RoundHalfEven))
+		assert.Nil(err)
+		assert.Equal(int64(6790), m.Amount())
+	})
+
+	t.Run("inverse", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.SGD.New(135, 0), money.USD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(10000), m.Amount())
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.USD.New(-10, 0).Signed(), money.SGD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(-1350), m.Amount())
+		assert.True(m.IsSigned())
+		assert.Nil(m.Validate())
+
+		b, err := money.ConvertBig(money.USD.New(-10, 0).Signed().Big(), money.SGD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(-1350), b.Amount().Int64())
+		assert.True(b.IsSigned())
+		assert.Nil(b.Validate())
+	})
+
+	t.Run("exponents", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.USD.New(1, 0), money.JPY, rates)
+		assert.Nil(err)
+		assert.Equal(int64(149), m.Amount())
+
+		m, err = money.Convert(money.JPY.New(14852, 0), money.USD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(10000), m.Amount())
+	})
+
+	t.Run("target unit", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.Convert(money.USD.New(50, 30), money.SGD.WithUnit(5), rates)
+		assert.Nil(err)
+		assert.Equal(int64(6790), m.Amount())
+		assert.Equal(int64(5), m.Unit())
+	})
+
+	t.Run("big", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m, err := money.ConvertBig(money.NewBigMoneyWithCurrency(big.NewInt(5030), money.USD), money.SGD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(6791), m.Amount().Int64())
+		assert.Equal(money.SGD, m.Currency())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.Convert(money.USD.New(1, 0), money.EUR, rates)
+		assert.True(errors.Is(err, money.ErrRateNotFound))
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.Convert(money.NewMoneyWithCurrency(int16(30000), money.USD), money.JPY, rates)
+		assert.True(errors.Is(err, money.ErrOverflow))
+	})
+}
+
+func TestReadRatesCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	rates, err := money.ReadRatesCSV(strings.NewReader("from,to,rate\nUSD,SGD,1.35\neur,usd,1.0919\n"))
+	assert.Nil(err)
+
+	r, err := rates.Rate(money.EUR, money.USD)
+	assert.Nil(err)
+	assert.Equal(big.NewRat(10919, 10000), r)
+
+	_, err = money.ReadRatesCSV(strings.NewReader("USD,SGD,1.35\nUSD,MYR,abc\n"))
+	assert.True(errors.Is(err, money.ErrSyntax))
+
+	_, err = money.ReadRatesCSV(strings.NewReader("USD,SGD,-1\n"))
+	assert.True(errors.Is(err, money.ErrRateInvalid))
+}
+
+func TestReadECBRates(t *testing.T) {
+	assert := assert.New(t)
+
+	rates, err := money.ReadECBRates(strings.NewReader(ecbXML))
+	assert.Nil(err)
+
+	r, err := rates.Rate(money.EUR, money.USD)
+	assert.Nil(err)
+	assert.Equal(big.NewRat(10919, 10000), r, "latest day")
+
+	m, err := money.Convert(money.IDR.New(16_982_670, 0), money.EUR, rates)
+	assert.Nil(err)
+	assert.Equal(int64(100000), m.Amount())
+}
+
+func TestReadECBRatesUnordered(t *testing.T) {
+	assert := assert.New(t)
+
+	rates, err := money.ReadECBRates(strings.NewReader(`<Envelope><Cube>
+		<Cube time="2024-01-02"><Cube currency="USD" rate="1.0956"/></Cube>
+		<Cube time="2024-01-03"><Cube currency="USD" rate="1.0919"/></Cube>
+	</Cube></Envelope>`))
+	assert.Nil(err)
+
+	r, err := rates.Rate(money.EUR, money.USD)
+	assert.Nil(err)
+	assert.Equal(big.NewRat(10919, 10000), r, "latest day")
+
+	_, err = money.ReadECBRates(strings.NewReader(`<Envelope><Cube><Cube time="03/01/2024"/></Cube></Envelope>`))
+	assert.True(errors.Is(err, money.ErrSyntax))
+}
+
+func TestLoadRatesFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "rates.csv")
+	xmlPath := filepath.Join(dir, "rates.xml")
+	assert.Nil(os.WriteFile(csvPath, []byte("USD,SGD,1.35\n"), 0o644))
+	assert.Nil(os.WriteFile(xmlPath, []byte(ecbXML), 0o644))
+
+	rates, err := money.LoadRatesFile(csvPath)
+	assert.Nil(err)
+	_, err = rates.Rate(money.USD, money.SGD)
+	assert.Nil(err)
+
+	rates, err = money.LoadRatesFile(xmlPath)
+	assert.Nil(err)
+	_, err = rates.Rate(money.EUR, money.JPY)
+	assert.Nil(err)
+
+	_, err = money.LoadRatesFile(filepath.Join(dir, "rates.txt"))
+	assert.NotNil(err)
+}
@@ -0,0 +1,220 @@
+package money
+
+import (
+	"math"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// The fast paths below compute Allocate and Discount with 128-bit
+// intermediates instead of math/big. They report false whenever a value does
+// not fit in 64 bits, and the callers fall back to math/big, so the results
+// are always the same.
+
+// mulDivRound returns a * b / d rounded with the mode, and false if the
+// result does not fit in a uint64.
+func mulDivRound(a, b, d uint64, mode RoundingMode) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= d {
+		return 0, false
+	}
+
+	q, r := bits.Div64(hi, lo, d)
+	if r == 0 {
+		return q, true
+	}
+
+	var half int
+	switch rest := d - r; {
+	case r < rest:
+		half = -1
+	case r > rest:
+		half = 1
+	}
+
+	if roundAway(false, q%2 == 0, half, mode) {
+		if q == math.MaxUint64 {
+			return 0, false
+		}
+		q++
+	}
+
+	return q, true
+}
+
+// sumUint64 returns the sum of the non-negative ratios, and false if it does
+// not fit in a uint64.
+func sumUint64[T constraints.Integer](ratios []T) (uint64, bool) {
+	var total, carry uint64
+	for _, r := range ratios {
+		total, carry = bits.Add64(total, uint64(r), 0)
+		if carry != 0 {
+			return 0, false
+		}
+	}
+
+	return total, true
+}
+
+// fitUint64 converts n to T, and reports whether it fits.
+func fitUint64[T constraints.Integer](n uint64) (T, bool) {
+	res := T(n)
+	return res, res >= 0 && uint64(res) == n
+}
+
+// allocateFast is the fast path of allocate.
+func allocateFast[T constraints.Integer](dst []T, amount, unit T, ratios []T, total uint64, mode RoundingMode) bool {
+	units := uint64(amount / unit)
+
+	left := amount
+	for i := 0; i < len(ratios)-1; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+
+		q, ok := mulDivRound(units, uint64(ratios[i]), total, mode)
+		if !ok {
+			return false
+		}
+
+		hi, lo := bits.Mul64(q, uint64(unit))
+		share, ok := fitUint64[T](lo)
+		if hi != 0 || !ok {
+			return false
+		}
+
+		if share > left {
+			share = left
+		}
+
+		dst[i] = share
+		left -= share
+	}
+
+	dst[len(dst)-1] = left
+
+	return true
+}
+
+// allocateLargestRemainderFast is the fast path of allocateLargestRemainder.
+// Instead of sorting the remainders, which needs buffers, it searches for the
+// smallest remainder that receives a unit, and recomputes the remainders on
+// each pass.
+func allocateLargestRemainderFast[T constraints.Integer](dst []T, amount, unit T, ratios []T, total uint64) bool {
+	units := uint64(amount / unit)
+
+	left := units
+	for i, r := range ratios {
+		hi, lo := bits.Mul64(units, uint64(r))
+		if hi >= total {
+			return false
+		}
+
+		q, _ := bits.Div64(hi, lo, total)
+
+		// The quota is at most the units, which fit in T.
+		dst[i] = T(q)
+		left -= q
+	}
+
+	// Fewer units are left than there are ratios.
+	if left > 0 {
+		rem := func(i int) uint64 {
+			hi, lo := bits.Mul64(units, uint64(ratios[i]))
+			_, r := bits.Div64(hi, lo, total)
+			return r
+		}
+
+		atLeast := func(min uint64) (n uint64) {
+			for i := range ratios {
+				if rem(i) >= min {
+					n++
+				}
+			}
+
+			return n
+		}
+
+		// Find the largest remainder min such that at least left remainders
+		// are min or more. All remainders above min receive a unit, and the
+		// rest go to the first remainders that equal min, like a stable sort.
+		lo, hi := uint64(0), total-1
+		for lo < hi {
+			mid := lo + (hi-lo+1)/2
+			if atLeast(mid) >= left {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+
+		for i := range ratios {
+			if rem(i) > lo {
+				dst[i]++
+				left--
+			}
+		}
+
+		for i := 0; left > 0; i++ {
+			if rem(i) == lo {
+				dst[i]++
+				left--
+			}
+		}
+	}
+
+	for i := range dst {
+		dst[i] *= unit
+	}
+
+	return true
+}
+
+// discountFast is the fast path of Discount, for the percent num/den.
+func discountFast[T constraints.Integer](amount, unit T, num, den uint64, mode RoundingMode) (T, bool) {
+	if isMin(amount) {
+		return 0, false
+	}
+
+	abs := amount
+	if abs < 0 {
+		abs = -abs
+	}
+
+	q, ok := mulDivRound(uint64(abs/unit), num, den, mode)
+	if !ok {
+		return 0, false
+	}
+
+	hi, lo := bits.Mul64(q, uint64(unit))
+	res, ok := fitUint64[T](lo)
+	if hi != 0 || !ok {
+		return 0, false
+	}
+
+	if amount < 0 {
+		res = -res
+	}
+
+	return res, true
+}
+
+// percentFrac returns the percent as num/den of one, and false if either does
+// not fit in a uint64.
+func percentFrac(p Percenter) (num, den uint64, ok bool) {
+	if p, ok := p.(Percent); ok {
+		return uint64(p), 100, true
+	}
+
+	r := p.Percentage().r
+	if r == nil {
+		return 0, 1, true
+	}
+
+	if !r.Num().IsUint64() || !r.Denom().IsUint64() {
+		return 0, 0, false
+	}
+
+	return r.Num().Uint64(), r.Denom().Uint64(), true
+}
@@ -0,0 +1,176 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The fast paths must give the same results as math/big, bit for bit.
+
+func TestMulDivRound(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100_000; i++ {
+		a, b, d := r.Uint64()>>r.Intn(64), r.Uint64()>>r.Intn(64), 1+r.Uint64()>>r.Intn(64)
+		mode := RoundingMode(r.Intn(7))
+
+		x := new(big.Rat).SetFrac(new(big.Int).Mul(bigIntFromUint64(a), bigIntFromUint64(b)), bigIntFromUint64(d))
+		want := round(x, mode)
+
+		got, ok := mulDivRound(a, b, d, mode)
+		assert.Equal(want.IsUint64(), ok, "%d * %d / %d", a, b, d)
+		if ok {
+			assert.Equal(want.Uint64(), got, "%d * %d / %d %s", a, b, d, mode)
+		}
+	}
+}
+
+func TestAllocateFast(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20_000; i++ {
+		unit := int64(1 + r.Intn(10))
+		amount := r.Int63() >> r.Intn(63) / unit * unit
+		ratios := make([]int64, 1+r.Intn(20))
+		for j := range ratios {
+			ratios[j] = r.Int63() >> r.Intn(63)
+		}
+
+		total, ok := sumUint64(ratios)
+		if !ok || total == 0 {
+			continue
+		}
+
+		mode := RoundingMode(r.Intn(7))
+
+		got := make([]int64, len(ratios))
+		if allocateFast(got, amount, unit, ratios, total, mode) {
+			want := make([]int64, len(ratios))
+			assert.Nil(allocate(want, amount, unit, ratios, mode))
+			assert.Equal(want, got, "%d by %v %s", amount, ratios, mode)
+		}
+
+		got = make([]int64, len(ratios))
+		if allocateLargestRemainderFast(got, amount, unit, ratios, total) {
+			assert.Equal(allocateLargestRemainder(amount, unit, ratios), got, "%d by %v", amount, ratios)
+		}
+	}
+}
+
+func TestAllocateLargestRemainderFastTies(t *testing.T) {
+	assert := assert.New(t)
+
+	// Small values tie on the remainders often.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20_000; i++ {
+		amount := r.Int63n(50)
+		ratios := make([]int64, 1+r.Intn(10))
+		for j := range ratios {
+			ratios[j] = r.Int63n(5)
+		}
+
+		total, _ := sumUint64(ratios)
+		if total == 0 {
+			continue
+		}
+
+		got := make([]int64, len(ratios))
+		assert.True(allocateLargestRemainderFast(got, amount, 1, ratios, total))
+		assert.Equal(allocateLargestRemainder(amount, 1, ratios), got, "%d by %v", amount, ratios)
+	}
+}
+
+func TestDiscountFast(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20_000; i++ {
+		unit := int64(1 + r.Intn(10))
+		amount := (r.Int63()>>r.Intn(63) - math.MaxInt64>>1) / unit * unit
+		num, den := uint64(r.Intn(100_000)), uint64(1+r.Intn(100_000))
+		mode := RoundingMode(r.Intn(7))
+
+		got, ok := discountFast(amount, unit, num, den, mode)
+
+		want := quantize(big.NewInt(amount), new(big.Rat).SetFrac(bigIntFromUint64(num), bigIntFromUint64(den)), big.NewInt(unit), mode)
+		assert.Equal(want.IsInt64(), ok, "%d * %d / %d", amount, num, den)
+		if ok {
+			assert.Equal(want.Int64(), got, "%d * %d / %d %s", amount, num, den, mode)
+		}
+	}
+}
+
+func TestDiscountFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMoney[int64](math.MaxInt64, 1)
+	res, err := m.TryDiscountPercentage(NewPercentage(50, 1))
+	assert.Nil(err)
+	assert.Equal(int64(math.MaxInt64/2+1), res)
+
+	_, err = m.TryDiscountPercentage(NewPercentage(200, 1), WithUncappedPercent())
+	assert.ErrorIs(err, ErrOverflow)
+
+	_, err = m.TryDiscountPercentage(NewPercentage(200, 1))
+	assert.ErrorIs(err, ErrPercentOutOfRange)
+}
+
+func BenchmarkAllocate(b *testing.B) {
+	m := NewMoney[int64](1_000_000_00, 1)
+	ratios := []int64{1, 2, 3, 5, 8, 13, 21, 34, 55, 89}
+	total, _ := sumUint64(ratios)
+	dst := make([]int64, len(ratios))
+
+	b.Run("fast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			allocateFast(dst, m.amount, m.unit, ratios, total, RoundFloor)
+		}
+	})
+
+	b.Run("big", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = allocate(dst, m.amount, m.unit, ratios, RoundFloor)
+		}
+	})
+
+	b.Run("largest-remainder/fast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			allocateLargestRemainderFast(dst, m.amount, m.unit, ratios, total)
+		}
+	})
+
+	b.Run("largest-remainder/big", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			allocateLargestRemainder(m.amount, m.unit, ratios)
+		}
+	})
+}
+
+func BenchmarkDiscount(b *testing.B) {
+	m := NewMoney[int64](123_456_78, 1)
+	p := NewPercentage(125, 10)
+
+	b.Run("fast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = m.TryDiscountPercentage(p)
+		}
+	})
+
+	b.Run("big", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = discount(bigIntFromInteger(m.amount), bigIntFromInteger(m.unit), p, nil)
+		}
+	})
+}
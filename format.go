@@ -0,0 +1,264 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrUnknownLocale = errors.New("money: unknown locale")
+
+// NegativeStyle decides how negative amounts are displayed.
+type NegativeStyle int
+
+const (
+	// NegativeLeading places the minus sign before the symbol, e.g. -$1.00.
+	NegativeLeading NegativeStyle = iota
+
+	// NegativeAfterSymbol places the minus sign after a leading symbol, e.g.
+	// $-1.00.
+	NegativeAfterSymbol
+
+	// NegativeParentheses wraps the amount in parentheses, e.g. ($1.00).
+	NegativeParentheses
+)
+
+// Locale describes how amounts are displayed in a region.
+type Locale struct {
+	// Tag is the BCP 47 language tag, e.g. en-US.
+	Tag string
+
+	// Group separates every three digits of the major units.
+	Group string
+
+	// Decimal separates the major from the minor units.
+	Decimal string
+
+	// SymbolAfter places the symbol after the amount.
+	SymbolAfter bool
+
+	// SymbolSpace separates the symbol and the amount with a space.
+	SymbolSpace bool
+
+	// Negative decides how negative amounts are displayed.
+	Negative NegativeStyle
+}
+
+var (
+	DeDE = Locale{Tag: "de-DE", Group: ".", Decimal: ",", SymbolAfter: true, SymbolSpace: true}
+	EnGB = Locale{Tag: "en-GB", Group: ",", Decimal: "."}
+	EnSG = Locale{Tag: "en-SG", Group: ",", Decimal: "."}
+	EnUS = Locale{Tag: "en-US", Group: ",", Decimal: "."}
+	FrFR = Locale{Tag: "fr-FR", Group: " ", Decimal: ",", SymbolAfter: true, SymbolSpace: true}
+	IdID = Locale{Tag: "id-ID", Group: ".", Decimal: ",", SymbolSpace: true}
+	JaJP = Locale{Tag: "ja-JP", Group: ",", Decimal: "."}
+	MsMY = Locale{Tag: "ms-MY", Group: ",", Decimal: "."}
+	NlNL = Locale{Tag: "nl-NL", Group: ".", Decimal: ",", SymbolSpace: true, Negative: NegativeAfterSymbol}
+	DeCH = Locale{Tag: "de-CH", Group: "’", Decimal: ".", SymbolSpace: true, Negative: NegativeAfterSymbol}
+
+	// Accounting displays negative amounts in parentheses, as in financial
+	// statements.
+	Accounting = Locale{Tag: "en-US-u-cf-account", Group: ",", Decimal: ".", Negative: NegativeParentheses}
+)
+
+var locales = []Locale{DeCH, DeDE, EnGB, EnSG, EnUS, FrFR, IdID, JaJP, MsMY, NlNL, Accounting}
+
+var localeByTag = make(map[string]Locale, len(locales))
+
+// localeByCurrency is the default locale used by String.
+var localeByCurrency = map[string]Locale{
+	"CHF": DeCH,
+	"EUR": FrFR,
+	"GBP": EnGB,
+	"IDR": IdID,
+	"JPY": JaJP,
+	"MYR": MsMY,
+	"SGD": EnSG,
+	"USD": EnUS,
+}
+
+// defaultLocale is used for currencies without a default locale, and places
+// the symbol after the amount, e.g. 1,234.56 AED.
+var defaultLocale = Locale{Group: ",", Decimal: ".", SymbolAfter: true, SymbolSpace: true}
+
+func init() {
+	for _, l := range locales {
+		localeByTag[strings.ToLower(l.Tag)] = l
+	}
+}
+
+// LocaleByTag returns the built-in locale for the language tag. The lookup is
+// case-insensitive.
+func LocaleByTag(tag string) (Locale, error) {
+	l, ok := localeByTag[strings.ToLower(tag)]
+	if !ok {
+		return Locale{}, fmt.Errorf("%w: %q", ErrUnknownLocale, tag)
+	}
+
+	return l, nil
+}
+
+// Locales returns all built-in locales.
+func Locales() []Locale {
+	res := make([]Locale, len(locales))
+	copy(res, locales)
+
+	return res
+}
+
+// LocaleOf returns the locale used by String for the currency.
+func LocaleOf(c Currency) Locale {
+	if l, ok := localeByCurrency[c.Code]; ok {
+		return l
+	}
+
+	return defaultLocale
+}
+
+// Format returns the amount in major units, e.g. $50.30 for 5030 cents.
+func Format[T constraints.Integer](m *Money[T], l Locale) string {
+	return l.format(bigIntFromInteger(m.amount), m.currency)
+}
+
+// FormatBig is like Format, but for BigMoney.
+func FormatBig(m *BigMoney, l Locale) string {
+	return l.format(m.amount, m.currency)
+}
+
+// String formats the amount with the default locale of the currency. Without
+// a currency, the amount is returned as is. String and Format have value
+// receivers, so that Money values print like pointers.
+func (m Money[T]) String() string {
+	if m.currency.IsZero() {
+		return fmt.Sprint(m.amount)
+	}
+
+	return Format(&m, LocaleOf(m.currency))
+}
+
+// Format implements fmt.Formatter. The verbs %v and %s format the amount
+// with the default locale of the currency, %q quotes it, and %d prints the
+// amount in the smallest unit.
+func (m Money[T]) Format(f fmt.State, verb rune) {
+	formatVerb(f, verb, m.String(), fmt.Sprint(m.amount))
+}
+
+// String is like Money.String.
+func (m BigMoney) String() string {
+	if m.amount == nil {
+		m.amount = zero
+	}
+
+	if m.currency.IsZero() {
+		return m.amount.String()
+	}
+
+	return FormatBig(&m, LocaleOf(m.currency))
+}
+
+// Format implements fmt.Formatter, see Money.Format.
+func (m BigMoney) Format(f fmt.State, verb rune) {
+	if m.amount == nil {
+		m.amount = zero
+	}
+
+	formatVerb(f, verb, m.String(), m.amount.String())
+}
+
+func formatVerb(f fmt.State, verb rune, s, d string) {
+	switch verb {
+	case 'v', 's':
+		fmt.Fprint(f, padded(f, s))
+	case 'q':
+		fmt.Fprint(f, padded(f, fmt.Sprintf("%q", s)))
+	case 'd':
+		fmt.Fprint(f, padded(f, d))
+	default:
+		fmt.Fprintf(f, "%%!%c(money=%s)", verb, s)
+	}
+}
+
+func padded(f fmt.State, s string) string {
+	w, ok := f.Width()
+	if !ok {
+		return s
+	}
+
+	n := len([]rune(s))
+	if n >= w {
+		return s
+	}
+
+	pad := strings.Repeat(" ", w-n)
+	if f.Flag('-') {
+		return s + pad
+	}
+
+	return pad + s
+}
+
+func (l Locale) format(amount *big.Int, c Currency) string {
+	neg := amount.Sign() < 0
+	num := l.number(new(big.Int).Abs(amount), c.Exponent)
+
+	sym := c.Symbol
+	if sym == "" {
+		sym = c.Code
+	}
+
+	sep := ""
+	if l.SymbolSpace {
+		sep = " "
+	}
+
+	var s string
+	switch {
+	case sym == "":
+		s = num
+	case l.SymbolAfter:
+		s = num + sep + sym
+	case neg && l.Negative == NegativeAfterSymbol:
+		return sym + sep + "-" + num
+	default:
+		s = sym + sep + num
+	}
+
+	if !neg {
+		return s
+	}
+
+	if l.Negative == NegativeParentheses {
+		return "(" + s + ")"
+	}
+
+	return "-" + s
+}
+
+// number formats the non-negative amount with the exponent, e.g. 123456 with
+// exponent 2 as 1,234.56.
+func (l Locale) number(amount *big.Int, exp int) string {
+	digits := amount.String()
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	major, minor := digits[:len(digits)-exp], digits[len(digits)-exp:]
+
+	var sb strings.Builder
+	for i, d := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			sb.WriteString(l.Group)
+		}
+		sb.WriteRune(d)
+	}
+
+	if exp > 0 {
+		sb.WriteString(l.Decimal)
+		sb.WriteString(minor)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package money_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleFormat() {
+	fmt.Println(money.USD.New(50, 30))
+	fmt.Println(money.SGD.New(50, 30))
+	fmt.Println(money.IDR.New(534_000, 0))
+	fmt.Println(money.Format(money.EUR.New(1234, 56), money.FrFR))
+	// Output:
+	// $50.30
+	// S$50.30
+	// Rp 534.000
+	// 1 234,56 €
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency money.Currency
+		locale   money.Locale
+		expected string
+		scenario string
+	}{
+		{amount: 5030, currency: money.USD, locale: money.EnUS, expected: "$50.30", scenario: "en-US"},
+		{amount: 123456789, currency: money.USD, locale: money.EnUS, expected: "$1,234,567.89", scenario: "grouping"},
+		{amount: 5, currency: money.USD, locale: money.EnUS, expected: "$0.05", scenario: "minor units only"},
+		{amount: 0, currency: money.USD, locale: money.EnUS, expected: "$0.00", scenario: "zero"},
+		{amount: -5030, currency: money.USD, locale: money.EnUS, expected: "-$50.30", scenario: "negative leading"},
+		{amount: -5030, currency: money.USD, locale: money.Accounting, expected: "($50.30)", scenario: "negative parentheses"},
+		{amount: -123456, currency: money.EUR, locale: money.NlNL, expected: "€ -1.234,56", scenario: "negative after symbol"},
+		{amount: -123456, currency: money.EUR, locale: money.DeDE, expected: "-1.234,56 €", scenario: "negative with symbol after"},
+		{amount: 123456, currency: money.EUR, locale: money.FrFR, expected: "1 234,56 €", scenario: "fr-FR"},
+		{amount: 534000, currency: money.IDR, locale: money.IdID, expected: "Rp 534.000", scenario: "id-ID"},
+		{amount: 123456, currency: money.JPY, locale: money.JaJP, expected: "¥123,456", scenario: "exponent 0"},
+		{amount: 1234567, currency: money.KWD, locale: money.EnUS, expected: "KWD1,234.567", scenario: "exponent 3"},
+		{amount: 123456, currency: money.CHF, locale: money.DeCH, expected: "CHF 1’234.56", scenario: "de-CH"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoneyWithCurrency(test.amount, test.currency)
+			assert.Equal(test.expected, money.Format(m, test.locale))
+
+			b := money.NewBigMoneyWithCurrency(big.NewInt(test.amount), test.currency)
+			assert.Equal(test.expected, money.FormatBig(b, test.locale))
+		})
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.USD.New(50, 30)
+	assert.Equal("$50.30", m.String())
+	assert.Equal("$50.30", fmt.Sprintf("%v", m))
+	assert.Equal("$50.30", fmt.Sprintf("%s", m))
+	assert.Equal(`"$50.30"`, fmt.Sprintf("%q", m))
+	assert.Equal("5030", fmt.Sprintf("%d", m))
+	assert.Equal("  $50.30", fmt.Sprintf("%8v", m))
+	assert.Equal("$50.30  ", fmt.Sprintf("%-8v", m))
+	assert.Equal("%!x(money=$50.30)", fmt.Sprintf("%x", m))
+
+	assert.Equal("1,234.56 AED", money.NewMoneyWithCurrency(123456, money.AED).String())
+	assert.Equal("5030", money.NewMoney(5030, 1).String())
+
+	b := money.NewBigMoneyWithCurrency(big.NewInt(5030), money.SGD)
+	assert.Equal("S$50.30", fmt.Sprint(b))
+	assert.Equal("5030", fmt.Sprintf("%d", b))
+
+	// Values format like pointers, e.g. in structs.
+	assert.Equal("$50.30", fmt.Sprint(*m))
+	assert.Equal("5030", fmt.Sprintf("%d", *m))
+	assert.Equal("S$50.30", fmt.Sprintf("%v", *b))
+	assert.Equal("{$50.30}", fmt.Sprintf("%v", struct{ Total money.Money[int64] }{*m}))
+	assert.Equal("0", fmt.Sprint(money.BigMoney{}))
+}
+
+func TestLocaleByTag(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := money.LocaleByTag("fr-fr")
+	assert.Nil(err)
+	assert.Equal(money.FrFR, l)
+
+	_, err = money.LocaleByTag("xx-XX")
+	assert.True(errors.Is(err, money.ErrUnknownLocale))
+}
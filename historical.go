@@ -0,0 +1,234 @@
+package money
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HistoricalRateProvider returns the exchange rate that was effective at a
+// point in time.
+type HistoricalRateProvider interface {
+	RateAt(from, to Currency, at time.Time) (*ExchangeRate, error)
+}
+
+// ExchangeRate is a rate, and the legs it was derived from.
+type ExchangeRate struct {
+	Rate *big.Rat
+	Legs []RateLeg
+}
+
+// RateLeg is a rate between two currencies, as published at Time.
+type RateLeg struct {
+	From Currency
+	To   Currency
+	Rate *big.Rat
+	Time time.Time
+}
+
+// Path returns the currencies the rate was derived through, e.g. SGD, EUR
+// and USD for a rate triangulated through EUR.
+func (r *ExchangeRate) Path() []Currency {
+	if len(r.Legs) == 0 {
+		return nil
+	}
+
+	res := []Currency{r.Legs[0].From}
+	for _, leg := range r.Legs {
+		res = append(res, leg.To)
+	}
+
+	return res
+}
+
+// HistoricalRates is a RateProvider that keeps every rate by the time it was
+// published. When no rate exists between two currencies, the rate is
+// triangulated through the base currency.
+type HistoricalRates struct {
+	base Currency
+
+	mu     sync.RWMutex
+	series map[[2]string][]timedRate
+}
+
+type timedRate struct {
+	time time.Time
+	rate *big.Rat
+}
+
+func NewHistoricalRates(base Currency) *HistoricalRates {
+	return &HistoricalRates{
+		base:   base,
+		series: make(map[[2]string][]timedRate),
+	}
+}
+
+// Set sets the rate for converting one major unit of from into to, published
+// at the given time. A rate published at the same time is replaced.
+func (h *HistoricalRates) Set(from, to Currency, at time.Time, rate *big.Rat) error {
+	if rate.Sign() <= 0 {
+		return fmt.Errorf("%w: %s to %s at %s", ErrRateInvalid, from, to, rate.RatString())
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := [2]string{from.Code, to.Code}
+	s := h.series[key]
+	i := sort.Search(len(s), func(i int) bool {
+		return !s[i].time.Before(at)
+	})
+
+	tr := timedRate{time: at, rate: new(big.Rat).Set(rate)}
+	switch {
+	case i < len(s) && s[i].time.Equal(at):
+		s[i] = tr
+	default:
+		s = append(s, timedRate{})
+		copy(s[i+1:], s[i:])
+		s[i] = tr
+	}
+	h.series[key] = s
+
+	return nil
+}
+
+// RateAt returns the most recent rate published at or before the time.
+func (h *HistoricalRates) RateAt(from, to Currency, at time.Time) (*ExchangeRate, error) {
+	if from.Equal(to) {
+		return &ExchangeRate{Rate: big.NewRat(1, 1)}, nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if leg, ok := h.leg(from, to, at); ok {
+		return &ExchangeRate{
+			Rate: new(big.Rat).Set(leg.Rate),
+			Legs: []RateLeg{leg},
+		}, nil
+	}
+
+	if !from.Equal(h.base) && !to.Equal(h.base) {
+		in, okIn := h.leg(from, h.base, at)
+		out, okOut := h.leg(h.base, to, at)
+		if okIn && okOut {
+			return &ExchangeRate{
+				Rate: new(big.Rat).Mul(in.Rate, out.Rate),
+				Legs: []RateLeg{in, out},
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s to %s at %s", ErrRateNotFound, from, to, at.Format(time.RFC3339))
+}
+
+// Rate implements RateProvider with the latest rates.
+func (h *HistoricalRates) Rate(from, to Currency) (*big.Rat, error) {
+	return h.At(time.Now()).Rate(from, to)
+}
+
+// At returns a RateProvider with the rates effective at the time, e.g. to
+// reproduce yesterday's conversions.
+func (h *HistoricalRates) At(at time.Time) RateProvider {
+	return ratesAt{p: h, at: at}
+}
+
+// leg returns the direct or inverse rate at the time. The lock must be held.
+func (h *HistoricalRates) leg(from, to Currency, at time.Time) (RateLeg, bool) {
+	if tr, ok := h.latest([2]string{from.Code, to.Code}, at); ok {
+		return RateLeg{From: from, To: to, Rate: new(big.Rat).Set(tr.rate), Time: tr.time}, true
+	}
+
+	if tr, ok := h.latest([2]string{to.Code, from.Code}, at); ok {
+		return RateLeg{From: from, To: to, Rate: new(big.Rat).Inv(tr.rate), Time: tr.time}, true
+	}
+
+	return RateLeg{}, false
+}
+
+func (h *HistoricalRates) latest(key [2]string, at time.Time) (timedRate, bool) {
+	s := h.series[key]
+
+	// Find the first rate after the time, the one before is the latest.
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].time.After(at)
+	})
+	if i == 0 {
+		return timedRate{}, false
+	}
+
+	return s[i-1], true
+}
+
+type ratesAt struct {
+	p  HistoricalRateProvider
+	at time.Time
+}
+
+func (r ratesAt) Rate(from, to Currency) (*big.Rat, error) {
+	er, err := r.p.RateAt(from, to, r.at)
+	if err != nil {
+		return nil, err
+	}
+
+	return er.Rate, nil
+}
+
+// ReadECBHistory reads every day of the euro foreign exchange reference rates
+// published by the European Central Bank, with EUR as the base. The rates are
+// published at 16:00 CET, and dated at midnight UTC of that day.
+func ReadECBHistory(r io.Reader) (*HistoricalRates, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, err
+	}
+
+	type datedDay struct {
+		at    time.Time
+		rates []ecbRate
+	}
+
+	days := make([]datedDay, len(env.Days))
+	for i, day := range env.Days {
+		at, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrSyntax, day.Time)
+		}
+
+		days[i] = datedDay{at: at, rates: day.Rates}
+	}
+
+	// The latest day is published first. Set the oldest day first instead,
+	// so that each rate is appended to its series rather than inserted at
+	// the front.
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].at.Before(days[j].at)
+	})
+
+	rates := NewHistoricalRates(EUR)
+	for _, day := range days {
+		for _, er := range day.rates {
+			rate, ok := new(big.Rat).SetString(er.Rate)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s rate %q", ErrSyntax, er.Currency, er.Rate)
+			}
+
+			// Keep currencies missing from the registry by their code.
+			c, err := CurrencyByCode(er.Currency)
+			if err != nil {
+				c = Currency{Code: er.Currency}
+			}
+
+			if err := rates.Set(EUR, c, day.at, rate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return rates, nil
+}
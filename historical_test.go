@@ -0,0 +1,122 @@
+package money_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricalRates(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	rates := money.NewHistoricalRates(money.EUR)
+	assert.Nil(t, rates.Set(money.EUR, money.USD, day(2), big.NewRat(10956, 10000)))
+	assert.Nil(t, rates.Set(money.EUR, money.USD, day(4), big.NewRat(10953, 10000)))
+	assert.Nil(t, rates.Set(money.EUR, money.USD, day(3), big.NewRat(10919, 10000)))
+	assert.Nil(t, rates.Set(money.EUR, money.SGD, day(2), big.NewRat(14544, 10000)))
+	assert.Nil(t, rates.Set(money.USD, money.JPY, day(3), big.NewRat(14852, 100)))
+
+	t.Run("most recent at or before", func(t *testing.T) {
+		assert := assert.New(t)
+
+		r, err := rates.RateAt(money.EUR, money.USD, day(3).Add(12*time.Hour))
+		assert.Nil(err)
+		assert.Equal(big.NewRat(10919, 10000), r.Rate)
+		assert.Equal(day(3), r.Legs[0].Time)
+
+		r, err = rates.RateAt(money.EUR, money.USD, day(4))
+		assert.Nil(err)
+		assert.Equal(big.NewRat(10953, 10000), r.Rate)
+
+		_, err = rates.RateAt(money.EUR, money.USD, day(1))
+		assert.True(errors.Is(err, money.ErrRateNotFound))
+	})
+
+	t.Run("inverse", func(t *testing.T) {
+		assert := assert.New(t)
+
+		r, err := rates.RateAt(money.USD, money.EUR, day(2))
+		assert.Nil(err)
+		assert.Equal(big.NewRat(10000, 10956), r.Rate)
+		assert.Equal([]money.Currency{money.USD, money.EUR}, r.Path())
+	})
+
+	t.Run("triangulated", func(t *testing.T) {
+		assert := assert.New(t)
+
+		r, err := rates.RateAt(money.SGD, money.USD, day(3))
+		assert.Nil(err)
+		assert.Equal(new(big.Rat).Mul(big.NewRat(10000, 14544), big.NewRat(10919, 10000)), r.Rate)
+		assert.Equal([]money.Currency{money.SGD, money.EUR, money.USD}, r.Path())
+		assert.Equal(day(2), r.Legs[0].Time)
+		assert.Equal(day(3), r.Legs[1].Time)
+	})
+
+	t.Run("direct pair is preferred", func(t *testing.T) {
+		assert := assert.New(t)
+
+		r, err := rates.RateAt(money.JPY, money.USD, day(3))
+		assert.Nil(err)
+		assert.Equal([]money.Currency{money.JPY, money.USD}, r.Path())
+	})
+
+	t.Run("convert at", func(t *testing.T) {
+		assert := assert.New(t)
+
+		yesterday, err := money.Convert(money.EUR.New(100, 0), money.USD, rates.At(day(3)))
+		assert.Nil(err)
+		assert.Equal(int64(10919), yesterday.Amount())
+
+		latest, err := money.Convert(money.EUR.New(100, 0), money.USD, rates)
+		assert.Nil(err)
+		assert.Equal(int64(10953), latest.Amount())
+	})
+}
+
+func TestReadECBHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	rates, err := money.ReadECBHistory(strings.NewReader(ecbXML))
+	assert.Nil(err)
+
+	r, err := rates.RateAt(money.EUR, money.USD, time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal(big.NewRat(10956, 10000), r.Rate)
+
+	r, err = rates.RateAt(money.USD, money.JPY, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	assert.Nil(err)
+	assert.Equal([]money.Currency{money.USD, money.EUR, money.JPY}, r.Path())
+}
+
+func TestReadECBHistoryManyDays(t *testing.T) {
+	assert := assert.New(t)
+
+	// The history lists the latest day first, like the ECB feed.
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	var b strings.Builder
+	b.WriteString(`<Envelope><Cube>`)
+	for i := 5000; i >= 0; i-- {
+		fmt.Fprintf(&b, `<Cube time=%q><Cube currency="USD" rate="1.%04d"/></Cube>`, start.AddDate(0, 0, i).Format("2006-01-02"), i)
+	}
+	b.WriteString(`</Cube></Envelope>`)
+
+	rates, err := money.ReadECBHistory(strings.NewReader(b.String()))
+	assert.Nil(err)
+
+	for _, i := range []int{0, 1, 2500, 5000} {
+		r, err := rates.RateAt(money.EUR, money.USD, start.AddDate(0, 0, i).Add(time.Hour))
+		assert.Nil(err)
+		assert.Equal(big.NewRat(int64(10000+i), 10000), r.Rate, "day %d", i)
+	}
+
+	_, err = rates.RateAt(money.EUR, money.USD, start.Add(-time.Hour))
+	assert.True(errors.Is(err, money.ErrRateNotFound))
+}
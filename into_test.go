@@ -0,0 +1,155 @@
+package money_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitInto(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		n        uint
+		opts     []money.Option
+		scenario string
+	}{
+		{amount: 1000, n: 3, scenario: "remainder last"},
+		{amount: 1000, n: 3, opts: []money.Option{money.WithRounding(money.RoundCeiling)}, scenario: "round up"},
+		{amount: 1000, n: 7, opts: []money.Option{money.WithSpread(money.SpreadFirst)}, scenario: "spread first"},
+		{amount: 1000, n: 7, opts: []money.Option{money.WithSpread(money.SpreadLast)}, scenario: "spread last"},
+		{amount: 1000, n: 7, opts: []money.Option{money.WithShuffle(42)}, scenario: "shuffle"},
+		{amount: -1000, n: 3, scenario: "negative"},
+		{amount: 1000, n: 0, scenario: "no parts"},
+	}
+
+	dst := make([]int64, 0, 8)
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney[int64](test.amount, 1).Signed()
+
+			// Leftovers of the previous call must not leak into the parts.
+			dst = append(dst[:0], 1, 2, 3, 4, 5, 6, 7, 8)
+
+			res, err := m.SplitInto(dst, test.n, test.opts...)
+			assert.Nil(err)
+			assert.Equal(m.Split(test.n, test.opts...), res)
+			assert.Len(res, int(test.n))
+			assert.Equal(cap(dst), cap(res), "reuses dst")
+		})
+	}
+}
+
+func TestSplitIntoGrows(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.NewMoney[int64](100, 1)
+	res, err := m.SplitInto(make([]int64, 0, 1), 3)
+	assert.Nil(err)
+	assert.Equal([]int64{33, 33, 34}, res)
+
+	res, err = m.SplitInto(nil, 0)
+	assert.Nil(err)
+	assert.NotNil(res)
+	assert.Empty(res)
+
+	_, err = money.NewMoney[int64](-100, 1).SplitInto(make([]int64, 3), 3)
+	assert.True(errors.Is(err, money.ErrNegativeAmount))
+}
+
+func TestAllocateInto(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		ratios   []int64
+		opts     []money.Option
+		scenario string
+	}{
+		{amount: 1000, ratios: []int64{1, 2, 3}, scenario: "remainder last"},
+		{amount: 1000, ratios: []int64{1, 0, 2}, scenario: "zero ratio"},
+		{amount: 1000, ratios: []int64{1, 1, 1}, opts: []money.Option{money.WithStrategy(money.LargestRemainder)}, scenario: "largest remainder"},
+		{amount: -1000, ratios: []int64{1, 2, 3}, scenario: "negative"},
+		{amount: 1000, ratios: []int64{1 << 62, 1 << 62, 1 << 62}, scenario: "ratios overflow uint64"},
+		{amount: 1000, ratios: []int64{1 << 62, 1 << 62, 1 << 62}, opts: []money.Option{money.WithStrategy(money.LargestRemainder)}, scenario: "largest remainder of ratios that overflow uint64"},
+		{amount: 1000, ratios: nil, scenario: "no ratios"},
+	}
+
+	dst := make([]int64, 0, 8)
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney[int64](test.amount, 1).Signed()
+			dst = append(dst[:0], 1, 2, 3, 4, 5, 6, 7, 8)
+
+			res, err := m.AllocateInto(dst, test.ratios, test.opts...)
+			assert.Nil(err)
+			assert.Equal(m.Allocate(test.ratios, test.opts...), res)
+			assert.Len(res, len(test.ratios))
+			assert.Equal(cap(dst), cap(res), "reuses dst")
+		})
+	}
+}
+
+func TestMapAllocator(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.NewMoney[int64](1000, 1)
+
+	var a money.MapAllocator[string, int64]
+	dst := map[string]int64{"stale": 1}
+
+	ratios := map[string]int64{"a": 1, "b": 2, "c": 3}
+	assert.Nil(a.AllocateInto(dst, m, ratios))
+	assert.Equal(money.AllocateMap(m, ratios), dst)
+
+	ratios = map[string]int64{"b": 1, "d": 1}
+	assert.Nil(a.AllocateInto(dst, m, ratios, money.WithStrategy(money.LargestRemainder)))
+	assert.Equal(map[string]int64{"b": 500, "d": 500}, dst)
+
+	err := a.AllocateInto(dst, m, map[string]int64{"a": -1})
+	assert.True(errors.Is(err, money.ErrRatioInvalid))
+}
+
+func TestIntoAllocs(t *testing.T) {
+	m := money.NewMoney[int64](1_000_000_00, 1)
+	neg := m.WithAmount(-1_000_000_01).Signed()
+	ratios := []int64{1, 2, 3, 5, 8, 13, 21, 34, 55, 89}
+	dst := make([]int64, len(ratios))
+
+	largest := []money.Option{money.WithStrategy(money.LargestRemainder)}
+	first := []money.Option{money.WithSpread(money.SpreadFirst), money.WithRounding(money.RoundHalfEven)}
+
+	tests := []struct {
+		f        func()
+		scenario string
+	}{
+		{f: func() { _, _ = m.SplitInto(dst, 7) }, scenario: "split"},
+		{f: func() { _, _ = neg.SplitInto(dst, 7) }, scenario: "split negative"},
+		{f: func() { _, _ = m.SplitInto(dst, 7, first...) }, scenario: "split with options"},
+		{f: func() { _, _ = m.AllocateInto(dst, ratios) }, scenario: "allocate"},
+		{f: func() { _, _ = neg.AllocateInto(dst, ratios) }, scenario: "allocate negative"},
+		{f: func() { _, _ = m.AllocateInto(dst, ratios, largest...) }, scenario: "allocate largest remainder"},
+		{f: func() { _, _ = neg.AllocateInto(dst, ratios, largest...) }, scenario: "allocate negative largest remainder"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert.Equal(t, 0.0, testing.AllocsPerRun(100, test.f))
+		})
+	}
+
+	t.Run("map", func(t *testing.T) {
+		ratioByKey := map[string]int64{"a": 1, "b": 2, "c": 3}
+		res := make(map[string]int64)
+
+		var a money.MapAllocator[string, int64]
+		assert.Nil(t, a.AllocateInto(res, m, ratioByKey, largest...))
+
+		assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() {
+			_ = a.AllocateInto(res, m, ratioByKey, largest...)
+		}))
+	})
+}
@@ -0,0 +1,341 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// JSONShape decides how Money and BigMoney are marshalled to JSON, see
+// MarshalJSONShape.
+type JSONShape int
+
+const (
+	// JSONObject marshals to {"amount":5030,"unit":1,"currency":"USD"}, with
+	// "signed":true for signed Money. BigMoney marshals the amount and unit
+	// as strings, so that large values survive JavaScript clients.
+	JSONObject JSONShape = iota
+
+	// JSONString marshals to "USD 50.30". The unit and signed mode are not
+	// included, and are taken from the receiver when unmarshalling, with the
+	// unit defaulting to the currency's unit.
+	JSONString
+)
+
+// plain formats amounts without grouping, e.g. 1234.56.
+var plain = Locale{Decimal: "."}
+
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Unit     json.RawMessage `json:"unit,omitempty"`
+	Currency *Currency       `json:"currency,omitempty"`
+	Signed   bool            `json:"signed,omitempty"`
+}
+
+// jsonValue is the Money read from either shape.
+type jsonValue struct {
+	amount   *big.Int
+	unit     *big.Int
+	currency Currency
+	signed   bool
+}
+
+// MarshalJSON marshals to JSONObject. It has a value receiver, so that Money
+// fields that are not pointers marshal too.
+func (m Money[T]) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONShape(JSONObject)
+}
+
+// MarshalJSONShape is like MarshalJSON, but in the shape, e.g. from the
+// MarshalJSON of a type that wraps Money. UnmarshalJSON accepts both shapes.
+func (m Money[T]) MarshalJSONShape(shape JSONShape) ([]byte, error) {
+	switch shape {
+	case JSONObject:
+	case JSONString:
+		return json.Marshal(jsonString(bigIntFromInteger(m.amount), m.currency))
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrJSONShapeInvalid, int(shape))
+	}
+
+	// The zero Money has no unit. Omit it, so that the unit defaults like
+	// for input without one.
+	var unit []byte
+	if m.unit != 0 {
+		unit = []byte(fmt.Sprint(m.unit))
+	}
+
+	return marshalJSONObject([]byte(fmt.Sprint(m.amount)), unit, m.currency, m.signed)
+}
+
+// UnmarshalJSON reads either shape. The Money is signed if the input or the
+// receiver is.
+func (m *Money[T]) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalJSON(b, bigIntFromInteger(m.unit))
+	if err != nil {
+		return err
+	}
+
+	a, ok := integerFromBigInt[T](v.amount)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOverflow, v.amount)
+	}
+
+	u, ok := integerFromBigInt[T](v.unit)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOverflow, v.unit)
+	}
+
+	res := Money[T]{
+		amount:   a,
+		unit:     u,
+		currency: v.currency,
+		signed:   v.signed || m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// MarshalJSON is like Money.MarshalJSON.
+func (m BigMoney) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONShape(JSONObject)
+}
+
+// MarshalJSONShape is like Money.MarshalJSONShape.
+func (m BigMoney) MarshalJSONShape(shape JSONShape) ([]byte, error) {
+	// The zero BigMoney has neither amount nor unit, see Money.MarshalJSONShape.
+	a := m.amount
+	if a == nil {
+		a = zero
+	}
+
+	switch shape {
+	case JSONObject:
+	case JSONString:
+		return json.Marshal(jsonString(a, m.currency))
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrJSONShapeInvalid, int(shape))
+	}
+
+	amount, err := json.Marshal(a.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var unit []byte
+	if m.unit != nil && m.unit.Sign() != 0 {
+		if unit, err = json.Marshal(m.unit.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalJSONObject(amount, unit, m.currency, m.signed)
+}
+
+// UnmarshalJSON is like Money.UnmarshalJSON.
+func (m *BigMoney) UnmarshalJSON(b []byte) error {
+	var unit *big.Int
+	if m.unit != nil {
+		unit = m.Unit()
+	}
+
+	v, err := unmarshalJSON(b, unit)
+	if err != nil {
+		return err
+	}
+
+	res := BigMoney{
+		amount:   v.amount,
+		unit:     v.unit,
+		currency: v.currency,
+		signed:   v.signed || m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the code.
+func (c Currency) MarshalText() ([]byte, error) {
+	return []byte(c.Code), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, looking up the
+// registered currency for the code.
+func (c *Currency) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*c = Currency{}
+		return nil
+	}
+
+	cur, err := CurrencyByCode(string(b))
+	if err != nil {
+		return err
+	}
+
+	*c = cur
+
+	return nil
+}
+
+func marshalJSONObject(amount, unit []byte, currency Currency, signed bool) ([]byte, error) {
+	obj := jsonMoney{
+		Amount: amount,
+		Unit:   unit,
+		Signed: signed,
+	}
+	if !currency.IsZero() {
+		obj.Currency = &currency
+	}
+
+	return json.Marshal(obj)
+}
+
+// jsonString formats the amount as the code followed by the amount in major
+// units, e.g. USD 50.30.
+func jsonString(amount *big.Int, c Currency) string {
+	s := plain.number(new(big.Int).Abs(amount), c.Exponent)
+	if amount.Sign() < 0 {
+		s = "-" + s
+	}
+
+	if c.IsZero() {
+		return s
+	}
+
+	return c.Code + " " + s
+}
+
+// unmarshalJSON reads either shape. Without a unit in the input, the unit
+// falls back to the given unit, then to the currency's unit.
+func unmarshalJSON(b []byte, unit *big.Int) (*jsonValue, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, err
+		}
+
+		amount, currency, err := parseJSONString(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonValue{
+			amount:   amount,
+			unit:     defaultUnit(unit, currency),
+			currency: currency,
+		}, nil
+	}
+
+	var obj jsonMoney
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+
+	v := &jsonValue{
+		signed: obj.Signed,
+	}
+	if obj.Currency != nil {
+		v.currency = *obj.Currency
+	}
+
+	var err error
+	v.amount, err = parseJSONInt(obj.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obj.Unit) == 0 {
+		v.unit = defaultUnit(unit, v.currency)
+		return v, nil
+	}
+
+	v.unit, err = parseJSONInt(obj.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func parseJSONString(s string) (*big.Int, Currency, error) {
+	var currency Currency
+	if code, num, ok := strings.Cut(s, " "); ok {
+		if err := currency.UnmarshalText([]byte(code)); err != nil {
+			return nil, Currency{}, err
+		}
+		s = num
+	}
+
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		s, neg = s[1:], true
+	}
+
+	amount, err := parseNumber(s, plain, currency.Exponent)
+	if err != nil {
+		return nil, Currency{}, fmt.Errorf("%w: %q", err, s)
+	}
+
+	if neg {
+		amount.Neg(amount)
+	}
+
+	return amount, currency, nil
+}
+
+// parseJSONInt reads integers from either JSON numbers or strings.
+func parseJSONInt(b json.RawMessage) (*big.Int, error) {
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, err
+		}
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSyntax, b)
+	}
+
+	return n, nil
+}
+
+func defaultUnit(unit *big.Int, c Currency) *big.Int {
+	switch {
+	case unit != nil && unit.Sign() != 0:
+		return unit
+	case !c.IsZero():
+		return big.NewInt(c.Unit)
+	default:
+		return big.NewInt(1)
+	}
+}
+
+// integerFromBigInt converts n to T, and reports whether n fits in T.
+func integerFromBigInt[T constraints.Integer](n *big.Int) (T, bool) {
+	var res T
+	switch {
+	case n.IsInt64():
+		res = T(n.Int64())
+	case n.IsUint64():
+		res = T(n.Uint64())
+	default:
+		return 0, false
+	}
+
+	return res, isEq(bigIntFromInteger(res), n)
+}
@@ -0,0 +1,199 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyJSON(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.SGD.WithUnit(5).New(50, 30)
+		b, err := json.Marshal(m)
+		assert.Nil(err)
+		assert.JSONEq(`{"amount":5030,"unit":5,"currency":"SGD"}`, string(b))
+
+		var got money.Money[int64]
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(m.Equal(&got))
+	})
+
+	t.Run("without currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		b, err := json.Marshal(money.NewMoney(100, 5))
+		assert.Nil(err)
+		assert.JSONEq(`{"amount":100,"unit":5}`, string(b))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.USD.New(50, 30)
+		b, err := m.MarshalJSONShape(money.JSONString)
+		assert.Nil(err)
+		assert.Equal(`"USD 50.30"`, string(b))
+
+		_, err = m.MarshalJSONShape(money.JSONShape(9))
+		assert.True(errors.Is(err, money.ErrJSONShapeInvalid))
+
+		var got money.Money[int64]
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(m.Equal(&got))
+	})
+
+	t.Run("string keeps the unit of the receiver", func(t *testing.T) {
+		assert := assert.New(t)
+
+		got := money.NewMoney[int64](0, 5)
+		assert.Nil(json.Unmarshal([]byte(`"SGD 50.30"`), got))
+		assert.Equal(int64(5030), got.Amount())
+		assert.Equal(int64(5), got.Unit())
+	})
+
+	t.Run("in a struct", func(t *testing.T) {
+		assert := assert.New(t)
+
+		type order struct {
+			Total *money.Money[int64] `json:"total"`
+		}
+
+		var o order
+		assert.Nil(json.Unmarshal([]byte(`{"total":{"amount":"534000","currency":"IDR"}}`), &o))
+		assert.Equal(int64(534000), o.Total.Amount())
+		assert.Equal(int64(1), o.Total.Unit())
+		assert.Equal(money.IDR, o.Total.Currency())
+	})
+
+	t.Run("by value", func(t *testing.T) {
+		assert := assert.New(t)
+
+		type payment struct {
+			P money.Money[int64]
+		}
+
+		b, err := json.Marshal(payment{P: *money.USD.New(50, 30)})
+		assert.Nil(err)
+		assert.JSONEq(`{"P":{"amount":5030,"unit":1,"currency":"USD"}}`, string(b))
+
+		var got payment
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(money.USD.New(50, 30).Equal(&got.P))
+	})
+
+	t.Run("validates", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var m money.Money[int64]
+		err := json.Unmarshal([]byte(`{"amount":5032,"unit":5,"currency":"SGD"}`), &m)
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+
+		err = json.Unmarshal([]byte(`"USD 50.305"`), &m)
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+
+		err = json.Unmarshal([]byte(`{"amount":1,"currency":"XYZ"}`), &m)
+		assert.True(errors.Is(err, money.ErrUnknownCurrency))
+
+		err = json.Unmarshal([]byte(`{"amount":-1}`), &m)
+		assert.True(errors.Is(err, money.ErrNegativeAmount))
+
+		var small money.Money[int8]
+		err = json.Unmarshal([]byte(`{"amount":1000}`), &small)
+		assert.True(errors.Is(err, money.ErrOverflow))
+	})
+}
+
+func TestBigMoneyJSON(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		assert := assert.New(t)
+
+		amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		m := money.NewBigMoneyWithCurrency(amount, money.USD)
+		b, err := json.Marshal(m)
+		assert.Nil(err)
+		assert.JSONEq(`{"amount":"123456789012345678901234567890","unit":"1","currency":"USD"}`, string(b))
+
+		var got money.BigMoney
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(m.Equal(&got))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewBigMoneyWithCurrency(big.NewInt(123456), money.KWD)
+		b, err := m.MarshalJSONShape(money.JSONString)
+		assert.Nil(err)
+		assert.Equal(`"KWD 123.456"`, string(b))
+
+		var got money.BigMoney
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(m.Equal(&got))
+	})
+
+	t.Run("by value", func(t *testing.T) {
+		assert := assert.New(t)
+
+		b, err := json.Marshal([]money.BigMoney{*money.NewBigMoneyWithCurrency(big.NewInt(5030), money.USD)})
+		assert.Nil(err)
+		assert.JSONEq(`[{"amount":"5030","unit":"1","currency":"USD"}]`, string(b))
+	})
+
+	t.Run("validates", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var m money.BigMoney
+		err := json.Unmarshal([]byte(`{"amount":"3","unit":"2"}`), &m)
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+	})
+}
+
+func TestZeroJSON(t *testing.T) {
+	t.Run("money", func(t *testing.T) {
+		assert := assert.New(t)
+
+		type order struct {
+			Total money.Money[int64]
+		}
+
+		b, err := json.Marshal(order{})
+		assert.Nil(err)
+		assert.JSONEq(`{"Total":{"amount":0}}`, string(b))
+
+		var got order
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(got.Total.IsZero())
+		assert.Equal(int64(1), got.Total.Unit())
+
+		b, err = money.Money[int64]{}.MarshalJSONShape(money.JSONString)
+		assert.Nil(err)
+		assert.Equal(`"0"`, string(b))
+	})
+
+	t.Run("big money", func(t *testing.T) {
+		assert := assert.New(t)
+
+		type order struct {
+			Total money.BigMoney
+		}
+
+		b, err := json.Marshal(order{})
+		assert.Nil(err)
+		assert.JSONEq(`{"Total":{"amount":"0"}}`, string(b))
+
+		var got order
+		assert.Nil(json.Unmarshal(b, &got))
+		assert.True(got.Total.IsZero())
+		assert.Equal(big.NewInt(1), got.Total.Unit())
+
+		b, err = money.BigMoney{}.MarshalJSONShape(money.JSONString)
+		assert.Nil(err)
+		assert.Equal(`"0"`, string(b))
+	})
+}
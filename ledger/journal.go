@@ -0,0 +1,446 @@
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/alextanhongpin/money"
+)
+
+var (
+	ErrTampered      = errors.New("ledger: journal has been tampered with")
+	ErrJournalBroken = errors.New("ledger: journal failed to write, reopen it")
+)
+
+// record is a line in the journal. The hash covers the bytes of the line
+// without the hash, including the hash of the previous record, so that
+// changing any record breaks the chain after it. The hash is always the last
+// field of the line.
+type record struct {
+	Seq         uint64           `json:"seq"`
+	Prev        string           `json:"prev"`
+	Account     *jsonAccount     `json:"account,omitempty"`
+	Transaction *jsonTransaction `json:"transaction,omitempty"`
+	Hash        string           `json:"hash,omitempty"`
+}
+
+type jsonAccount struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type jsonTransaction struct {
+	ID          string        `json:"id"`
+	Time        time.Time     `json:"time"`
+	Description string        `json:"description,omitempty"`
+	Postings    []jsonPosting `json:"postings"`
+}
+
+// jsonPosting spells out the amount, instead of relying on the JSON shape of
+// Money, which is configurable.
+type jsonPosting struct {
+	Account  string        `json:"account"`
+	Side     string        `json:"side"`
+	Amount   int64         `json:"amount"`
+	Unit     int64         `json:"unit"`
+	Signed   bool          `json:"signed,omitempty"`
+	Currency *jsonCurrency `json:"currency,omitempty"`
+}
+
+// jsonCurrency spells out the currency, so that currencies that are not
+// registered, e.g. XAU, replay as they were posted.
+type jsonCurrency struct {
+	Code     string `json:"code"`
+	Numeric  int    `json:"numeric,omitempty"`
+	Exponent int    `json:"exponent"`
+	Symbol   string `json:"symbol,omitempty"`
+}
+
+// Journal is an append-only JSON Lines file of account openings and
+// transactions, applied to a Ledger. Every record is synced to disk before
+// Open or Post returns.
+type Journal struct {
+	mu     sync.Mutex
+	f      *os.File
+	ledger *Ledger
+	seq    uint64
+	hash   string
+	err    error
+}
+
+// OpenJournal opens or creates the journal at the path, and replays it into
+// a new Ledger. A torn write at the end of the file, from a crash before the
+// record was synced, is discarded.
+func OpenJournal(path string) (*Journal, error) {
+	_, err := os.Stat(path)
+	created := errors.Is(err, os.ErrNotExist)
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync the directory, so that a new journal survives a crash.
+	if created {
+		if err := syncDir(filepath.Dir(path)); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	st, err := replay(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if err := f.Truncate(st.offset); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if _, err := f.Seek(st.offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Journal{
+		f:      f,
+		ledger: st.ledger,
+		seq:    st.seq,
+		hash:   st.hash,
+	}, nil
+}
+
+// Ledger returns a read-only view of the ledger with all journaled records
+// applied. Writes go through Open and Post, so that every change is
+// journaled.
+func (j *Journal) Ledger() Reader {
+	return readOnly{l: j.ledger}
+}
+
+// readOnly hides the writes of a Ledger, so that it cannot be converted back
+// to a *Ledger.
+type readOnly struct {
+	l *Ledger
+}
+
+func (r readOnly) Account(code string) (Account, error) {
+	return r.l.Account(code)
+}
+
+func (r readOnly) Accounts() []Account {
+	return r.l.Accounts()
+}
+
+func (r readOnly) Transactions() []Transaction {
+	return r.l.Transactions()
+}
+
+func (r readOnly) Balance(account string, currency money.Currency) (*money.Money[int64], error) {
+	return r.l.Balance(account, currency)
+}
+
+func (r readOnly) Entries(account string) ([]Entry, error) {
+	return r.l.Entries(account)
+}
+
+func (r readOnly) TrialBalance() (*TrialBalance, error) {
+	return r.l.TrialBalance()
+}
+
+// Open opens the account in the ledger, and journals it.
+func (j *Journal) Open(a Account) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	if err := j.ledger.Open(a); err != nil {
+		return err
+	}
+
+	return j.append(record{Account: toJSONAccount(a)})
+}
+
+// Post posts the transaction to the ledger, and journals it. Rejected
+// transactions are not journaled.
+func (j *Journal) Post(tx Transaction) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	if err := j.ledger.Post(tx); err != nil {
+		return err
+	}
+
+	return j.append(record{Transaction: toJSONTransaction(tx)})
+}
+
+func (j *Journal) Close() error {
+	return j.f.Close()
+}
+
+// append writes and syncs the record. On failure, the ledger is ahead of the
+// file, so the journal refuses further writes until it is reopened.
+func (j *Journal) append(r record) error {
+	r.Seq = j.seq + 1
+	r.Prev = j.hash
+
+	line, hash, err := marshalRecord(r)
+	if err == nil {
+		_, err = j.f.Write(line)
+	}
+	if err == nil {
+		err = j.f.Sync()
+	}
+	if err != nil {
+		j.err = fmt.Errorf("%w: %v", ErrJournalBroken, err)
+		return j.err
+	}
+
+	j.seq, j.hash = r.Seq, hash
+
+	return nil
+}
+
+// Replay rebuilds the ledger from the journal, verifying the hash chain and
+// that every transaction balances. A trailing line without a newline is a
+// torn write, and is ignored.
+func Replay(r io.Reader) (*Ledger, error) {
+	st, err := replay(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.ledger, nil
+}
+
+type replayState struct {
+	ledger *Ledger
+	seq    uint64
+	hash   string
+
+	// offset is the end of the last complete record.
+	offset int64
+}
+
+func replay(r io.Reader) (*replayState, error) {
+	st := &replayState{
+		ledger: New(),
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return st, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrTampered, st.seq+1, err)
+		}
+
+		if err := st.verify(line, rec); err != nil {
+			return nil, err
+		}
+
+		if err := st.apply(rec); err != nil {
+			return nil, fmt.Errorf("record %d: %w", rec.Seq, err)
+		}
+
+		st.seq, st.hash = rec.Seq, rec.Hash
+		st.offset += int64(len(line))
+	}
+}
+
+func (st *replayState) verify(line []byte, rec record) error {
+	if rec.Seq != st.seq+1 || rec.Prev != st.hash {
+		return fmt.Errorf("%w: record %d does not follow record %d", ErrTampered, rec.Seq, st.seq)
+	}
+
+	body, hash, ok := splitHash(line)
+	if !ok || hash != rec.Hash || hashBody(body) != hash {
+		return fmt.Errorf("%w: record %d hash mismatch", ErrTampered, rec.Seq)
+	}
+
+	return nil
+}
+
+func (st *replayState) apply(rec record) error {
+	switch {
+	case rec.Account != nil && rec.Transaction == nil:
+		a, err := rec.Account.account()
+		if err != nil {
+			return err
+		}
+
+		return st.ledger.Open(a)
+	case rec.Transaction != nil && rec.Account == nil:
+		tx, err := rec.Transaction.transaction()
+		if err != nil {
+			return err
+		}
+
+		return st.ledger.Post(tx)
+	default:
+		return fmt.Errorf("%w: record %d is neither an account nor a transaction", ErrTampered, rec.Seq)
+	}
+}
+
+// hashField precedes the hash, which ends the line.
+var hashField = []byte(`,"hash":"`)
+
+// marshalRecord returns the JSON line with the hash appended, and the hash.
+func marshalRecord(r record) ([]byte, string, error) {
+	r.Hash = ""
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash := hashBody(body)
+
+	line := append(body[:len(body)-1:len(body)-1], hashField...)
+	line = append(line, hash...)
+
+	return append(line, "\"}\n"...), hash, nil
+}
+
+// splitHash returns the line as it was hashed, and the hash.
+func splitHash(line []byte) ([]byte, string, bool) {
+	line = bytes.TrimSuffix(line, []byte("\"}\n"))
+	i := bytes.LastIndex(line, hashField)
+	if i < 0 {
+		return nil, "", false
+	}
+
+	body := append(line[:i:i], '}')
+
+	return body, string(line[i+len(hashField):]), true
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+func toJSONAccount(a Account) *jsonAccount {
+	return &jsonAccount{
+		Code: a.Code,
+		Name: a.Name,
+		Type: a.Type.String(),
+	}
+}
+
+func (ja *jsonAccount) account() (Account, error) {
+	for t := Asset; t <= Expense; t++ {
+		if t.String() == ja.Type {
+			return Account{Code: ja.Code, Name: ja.Name, Type: t}, nil
+		}
+	}
+
+	return Account{}, fmt.Errorf("ledger: unknown account type %q", ja.Type)
+}
+
+func toJSONTransaction(tx Transaction) *jsonTransaction {
+	postings := make([]jsonPosting, len(tx.Postings))
+	for i, p := range tx.Postings {
+		postings[i] = jsonPosting{
+			Account: p.Account,
+			Side:    p.Side.String(),
+			Amount:  p.Amount.Amount(),
+			Unit:    p.Amount.Unit(),
+			Signed:  p.Amount.IsSigned(),
+		}
+
+		if c := p.Amount.Currency(); !c.IsZero() {
+			postings[i].Currency = &jsonCurrency{
+				Code:     c.Code,
+				Numeric:  c.Numeric,
+				Exponent: c.Exponent,
+				Symbol:   c.Symbol,
+			}
+		}
+	}
+
+	return &jsonTransaction{
+		ID:          tx.ID,
+		Time:        tx.Time,
+		Description: tx.Description,
+		Postings:    postings,
+	}
+}
+
+func (jt *jsonTransaction) transaction() (Transaction, error) {
+	postings := make([]Posting, len(jt.Postings))
+	for i, jp := range jt.Postings {
+		var side Side
+		switch jp.Side {
+		case Debit.String():
+			side = Debit
+		case Credit.String():
+			side = Credit
+		default:
+			return Transaction{}, fmt.Errorf("%w: %q", ErrInvalidSide, jp.Side)
+		}
+
+		var currency money.Currency
+		if jc := jp.Currency; jc != nil {
+			currency = money.Currency{
+				Code:     jc.Code,
+				Numeric:  jc.Numeric,
+				Exponent: jc.Exponent,
+				Symbol:   jc.Symbol,
+			}
+		}
+
+		amount := money.NewMoneyWithCurrency(jp.Amount, currency.WithUnit(jp.Unit))
+		if jp.Signed {
+			amount = amount.Signed()
+		}
+
+		postings[i] = Posting{
+			Account: jp.Account,
+			Side:    side,
+			Amount:  amount,
+		}
+	}
+
+	return Transaction{
+		ID:          jt.ID,
+		Time:        jt.Time,
+		Description: jt.Description,
+		Postings:    postings,
+	}, nil
+}
@@ -0,0 +1,239 @@
+package ledger_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/money"
+	"github.com/alextanhongpin/money/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeJournal(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	j, err := ledger.OpenJournal(path)
+	assert.Nil(t, err)
+	defer j.Close()
+
+	assert.Nil(t, j.Open(ledger.Account{Code: "1000", Name: "Cash", Type: ledger.Asset}))
+	assert.Nil(t, j.Open(ledger.Account{Code: "4000", Name: "Sales", Type: ledger.Income}))
+	assert.Nil(t, j.Post(ledger.Transaction{
+		ID:   "tx1",
+		Time: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		Postings: []ledger.Posting{
+			{Account: "1000", Side: ledger.Debit, Amount: money.SGD.WithUnit(5).New(50, 30)},
+			{Account: "4000", Side: ledger.Credit, Amount: money.SGD.WithUnit(5).New(50, 30)},
+		},
+	}))
+
+	// Rejected transactions are not journaled.
+	err = j.Post(ledger.Transaction{
+		ID: "tx2",
+		Postings: []ledger.Posting{
+			{Account: "1000", Side: ledger.Debit, Amount: usd(1)},
+			{Account: "4000", Side: ledger.Credit, Amount: usd(2)},
+		},
+	})
+	assert.True(t, errors.Is(err, ledger.ErrUnbalanced))
+
+	return path
+}
+
+func TestJournal(t *testing.T) {
+	t.Run("reopen", func(t *testing.T) {
+		assert := assert.New(t)
+
+		path := writeJournal(t)
+
+		j, err := ledger.OpenJournal(path)
+		assert.Nil(err)
+		defer j.Close()
+
+		cash, err := j.Ledger().Balance("1000", money.SGD)
+		assert.Nil(err)
+		assert.True(money.SGD.WithUnit(5).New(50, 30).Equal(cash))
+
+		assert.Nil(j.Post(ledger.Transaction{
+			ID: "tx3",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: money.SGD.WithUnit(5).New(1, 0)},
+				{Account: "4000", Side: ledger.Credit, Amount: money.SGD.WithUnit(5).New(1, 0)},
+			},
+		}))
+
+		b, err := os.ReadFile(path)
+		assert.Nil(err)
+		assert.Equal(4, bytes.Count(b, []byte("\n")))
+
+		l, err := ledger.Replay(bytes.NewReader(b))
+		assert.Nil(err)
+		assert.Len(l.Transactions(), 2)
+		assert.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), l.Transactions()[0].Time)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		assert := assert.New(t)
+
+		b, err := os.ReadFile(writeJournal(t))
+		assert.Nil(err)
+
+		_, err = ledger.Replay(bytes.NewReader(bytes.Replace(b, []byte(`"amount":5030`), []byte(`"amount":5000`), 2)))
+		assert.True(errors.Is(err, ledger.ErrTampered))
+
+		lines := strings.SplitAfter(string(b), "\n")
+		_, err = ledger.Replay(strings.NewReader(lines[0] + lines[2]))
+		assert.True(errors.Is(err, ledger.ErrTampered), "removed record")
+
+		_, err = ledger.Replay(strings.NewReader("not json\n"))
+		assert.True(errors.Is(err, ledger.ErrTampered))
+	})
+
+	t.Run("torn write", func(t *testing.T) {
+		assert := assert.New(t)
+
+		path := writeJournal(t)
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		assert.Nil(err)
+		_, err = f.WriteString(`{"seq":4,"prev":`)
+		assert.Nil(err)
+		assert.Nil(f.Close())
+
+		j, err := ledger.OpenJournal(path)
+		assert.Nil(err)
+		assert.Nil(j.Open(ledger.Account{Code: "5000", Name: "Rent", Type: ledger.Expense}))
+		assert.Nil(j.Close())
+
+		b, err := os.ReadFile(path)
+		assert.Nil(err)
+
+		l, err := ledger.Replay(bytes.NewReader(b))
+		assert.Nil(err)
+		assert.Len(l.Accounts(), 3)
+	})
+
+	t.Run("unbalanced record", func(t *testing.T) {
+		assert := assert.New(t)
+
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		j, err := ledger.OpenJournal(path)
+		assert.Nil(err)
+		assert.Nil(j.Open(ledger.Account{Code: "1000", Type: ledger.Asset}))
+		assert.Nil(j.Open(ledger.Account{Code: "4000", Type: ledger.Income}))
+		assert.Nil(j.Close())
+
+		b, err := os.ReadFile(path)
+		assert.Nil(err)
+
+		// Forge a record with a valid hash chain, that does not balance.
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		var last struct {
+			Hash string `json:"hash"`
+		}
+		assert.Nil(json.Unmarshal([]byte(lines[1]), &last))
+
+		body := fmt.Sprintf(`{"seq":3,"prev":%q,"transaction":{"id":"tx1","time":"0001-01-01T00:00:00Z","postings":[`+
+			`{"account":"1000","side":"debit","amount":100,"unit":1,"currency":{"code":"USD","exponent":2}},`+
+			`{"account":"4000","side":"credit","amount":99,"unit":1,"currency":{"code":"USD","exponent":2}}]}}`, last.Hash)
+		sum := sha256.Sum256([]byte(body))
+		forged := strings.TrimSuffix(body, "}") + fmt.Sprintf(`,"hash":%q}`, hex.EncodeToString(sum[:])) + "\n"
+
+		_, err = ledger.Replay(strings.NewReader(string(b) + forged))
+		assert.True(errors.Is(err, ledger.ErrUnbalanced), err)
+	})
+
+	t.Run("hashes the bytes on disk", func(t *testing.T) {
+		assert := assert.New(t)
+
+		b, err := os.ReadFile(writeJournal(t))
+		assert.Nil(err)
+
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		var last struct {
+			Seq  int    `json:"seq"`
+			Hash string `json:"hash"`
+		}
+		assert.Nil(json.Unmarshal([]byte(lines[len(lines)-1]), &last))
+
+		// A record encoded differently from json.Marshal, e.g. by another
+		// version, still verifies.
+		body := fmt.Sprintf(`{ "prev": %q, "seq": %d, "account": {"type": "expense", "code": "5000", "name": "Rent"} }`, last.Hash, last.Seq+1)
+		sum := sha256.Sum256([]byte(body))
+		line := strings.TrimSuffix(body, "}") + fmt.Sprintf(`,"hash":%q}`, hex.EncodeToString(sum[:])) + "\n"
+
+		l, err := ledger.Replay(strings.NewReader(string(b) + line))
+		assert.Nil(err)
+		assert.Len(l.Accounts(), 3)
+
+		_, err = ledger.Replay(strings.NewReader(string(b) + strings.Replace(line, `"seq"`, `"seq" `, 1)))
+		assert.True(errors.Is(err, ledger.ErrTampered))
+	})
+
+	t.Run("unregistered currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		xau := money.Currency{Code: "XAU", Numeric: 959, Exponent: 3, Unit: 1, Symbol: "oz t"}
+
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		j, err := ledger.OpenJournal(path)
+		assert.Nil(err)
+		assert.Nil(j.Open(ledger.Account{Code: "1000", Type: ledger.Asset}))
+		assert.Nil(j.Open(ledger.Account{Code: "3000", Type: ledger.Equity}))
+		assert.Nil(j.Post(ledger.Transaction{
+			ID: "tx1",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: xau.New(1, 500).Signed()},
+				{Account: "3000", Side: ledger.Credit, Amount: xau.New(1, 500)},
+			},
+		}))
+		assert.Nil(j.Close())
+
+		j, err = ledger.OpenJournal(path)
+		assert.Nil(err)
+		defer j.Close()
+
+		tx := j.Ledger().Transactions()[0]
+		assert.Equal(xau, tx.Postings[0].Amount.Currency())
+		assert.True(tx.Postings[0].Amount.IsSigned())
+		assert.False(tx.Postings[1].Amount.IsSigned())
+		assert.Equal(int64(1500), tx.Postings[0].Amount.Amount())
+	})
+
+	t.Run("read-only ledger", func(t *testing.T) {
+		assert := assert.New(t)
+
+		j, err := ledger.OpenJournal(writeJournal(t))
+		assert.Nil(err)
+		defer j.Close()
+
+		_, ok := j.Ledger().(*ledger.Ledger)
+		assert.False(ok)
+
+		txs := j.Ledger().Transactions()
+		txs[0].Postings[0].Amount = usd(1)
+
+		cash, err := j.Ledger().Balance("1000", money.SGD)
+		assert.Nil(err)
+		assert.True(money.SGD.WithUnit(5).New(50, 30).Equal(cash))
+		assert.True(money.SGD.WithUnit(5).New(50, 30).Equal(j.Ledger().Transactions()[0].Postings[0].Amount))
+	})
+
+	t.Run("duplicated records", func(t *testing.T) {
+		assert := assert.New(t)
+
+		b, err := os.ReadFile(writeJournal(t))
+		assert.Nil(err)
+
+		_, err = ledger.Replay(bytes.NewReader(append(b, b...)))
+		assert.True(errors.Is(err, ledger.ErrTampered))
+	})
+}
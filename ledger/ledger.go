@@ -0,0 +1,484 @@
+// Package ledger implements a double-entry ledger on top of Money, where
+// every transaction must balance per currency.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alextanhongpin/money"
+)
+
+var (
+	ErrAccountExists  = errors.New("ledger: account already exists")
+	ErrUnknownAccount = errors.New("ledger: unknown account")
+	ErrNoPostings     = errors.New("ledger: transaction needs at least two postings")
+	ErrInvalidAmount  = errors.New("ledger: posting amount must be positive")
+	ErrUnbalanced     = errors.New("ledger: debits do not equal credits")
+	ErrInvalidSide    = errors.New("ledger: invalid side")
+)
+
+// AccountType decides the normal balance of an account.
+type AccountType int
+
+const (
+	Asset AccountType = iota
+	Liability
+	Equity
+	Income
+	Expense
+)
+
+func (t AccountType) String() string {
+	switch t {
+	case Asset:
+		return "asset"
+	case Liability:
+		return "liability"
+	case Equity:
+		return "equity"
+	case Income:
+		return "income"
+	case Expense:
+		return "expense"
+	default:
+		return fmt.Sprintf("AccountType(%d)", int(t))
+	}
+}
+
+// Normal returns the side that increases the balance of the account type.
+func (t AccountType) Normal() Side {
+	switch t {
+	case Asset, Expense:
+		return Debit
+	default:
+		return Credit
+	}
+}
+
+type Account struct {
+	Code string
+	Name string
+	Type AccountType
+}
+
+// Side is the debit or credit side of a posting.
+type Side int
+
+const (
+	Debit Side = iota
+	Credit
+)
+
+func (s Side) String() string {
+	switch s {
+	case Debit:
+		return "debit"
+	case Credit:
+		return "credit"
+	default:
+		return fmt.Sprintf("Side(%d)", int(s))
+	}
+}
+
+// Posting debits or credits an account.
+type Posting struct {
+	Account string
+	Side    Side
+	Amount  *money.Money[int64]
+}
+
+// Transaction is a journal entry, made of postings whose debits equal the
+// credits for each currency.
+type Transaction struct {
+	ID          string
+	Time        time.Time
+	Description string
+	Postings    []Posting
+}
+
+// Validate checks that the transaction balances. It does not check that the
+// accounts exist.
+func (tx *Transaction) Validate() error {
+	if len(tx.Postings) < 2 {
+		return fmt.Errorf("%w: %s", ErrNoPostings, tx.ID)
+	}
+
+	totals := make(map[string]*total)
+	codes := make([]string, 0)
+	for _, p := range tx.Postings {
+		if err := p.Amount.Validate(); err != nil {
+			return fmt.Errorf("%s: %s: %w", tx.ID, p.Account, err)
+		}
+
+		// Signed Money validates with a negative amount.
+		if p.Amount.Sign() <= 0 {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidAmount, tx.ID, p.Account)
+		}
+
+		code := p.Amount.Currency().Code
+		t, ok := totals[code]
+		if !ok {
+			t = newTotal(p.Amount)
+			totals[code] = t
+			codes = append(codes, code)
+		}
+
+		if err := t.add(p.Side, p.Amount); err != nil {
+			return fmt.Errorf("%s: %s: %w", tx.ID, p.Account, err)
+		}
+	}
+
+	for _, code := range codes {
+		t := totals[code]
+		if !t.debit.Equal(t.credit) {
+			return fmt.Errorf("%w: %s: %s debits %s, credits %s", ErrUnbalanced, tx.ID, code, t.debit, t.credit)
+		}
+	}
+
+	return nil
+}
+
+// Entry is a posting with the running balance of the account after it.
+type Entry struct {
+	TransactionID string
+	Time          time.Time
+	Posting       Posting
+
+	// Balance is positive on the normal side of the account.
+	Balance *money.Money[int64]
+}
+
+// Reader reads the accounts, transactions and balances of a Ledger, e.g. of
+// a Journal, without writing to it.
+type Reader interface {
+	Account(code string) (Account, error)
+	Accounts() []Account
+	Transactions() []Transaction
+	Balance(account string, currency money.Currency) (*money.Money[int64], error)
+	Entries(account string) ([]Entry, error)
+	TrialBalance() (*TrialBalance, error)
+}
+
+// Ledger keeps the accounts, the transactions posted to them, and their
+// balances. It is safe for concurrent use.
+type Ledger struct {
+	mu           sync.RWMutex
+	accounts     map[string]Account
+	transactions []Transaction
+	entries      map[string][]Entry
+	totals       map[string]map[string]*total
+}
+
+func New() *Ledger {
+	return &Ledger{
+		accounts: make(map[string]Account),
+		entries:  make(map[string][]Entry),
+		totals:   make(map[string]map[string]*total),
+	}
+}
+
+// Open adds the account to the ledger.
+func (l *Ledger) Open(a Account) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.accounts[a.Code]; ok {
+		return fmt.Errorf("%w: %s", ErrAccountExists, a.Code)
+	}
+
+	l.accounts[a.Code] = a
+
+	return nil
+}
+
+// Account returns the account for the code.
+func (l *Ledger) Account(code string) (Account, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	a, ok := l.accounts[code]
+	if !ok {
+		return Account{}, fmt.Errorf("%w: %s", ErrUnknownAccount, code)
+	}
+
+	return a, nil
+}
+
+// Accounts returns all accounts, sorted by code.
+func (l *Ledger) Accounts() []Account {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	res := make([]Account, 0, len(l.accounts))
+	for _, a := range l.accounts {
+		res = append(res, a)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Code < res[j].Code
+	})
+
+	return res
+}
+
+// Post records the transaction, and rejects it unless the debits equal the
+// credits for each currency.
+func (l *Ledger) Post(tx Transaction) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, p := range tx.Postings {
+		if _, ok := l.accounts[p.Account]; !ok {
+			return fmt.Errorf("%w: %s: %s", ErrUnknownAccount, tx.ID, p.Account)
+		}
+	}
+
+	// Add the amounts to copies of the totals, and keep them only if all
+	// postings add up, so that a rejected transaction, e.g. one that
+	// overflows a balance, leaves no trace.
+	type key struct {
+		account, code string
+	}
+
+	staged := make(map[key]*total)
+	entries := make([]Entry, len(tx.Postings))
+	for i, p := range tx.Postings {
+		k := key{account: p.Account, code: p.Amount.Currency().Code}
+		t, ok := staged[k]
+		if !ok {
+			t = newTotal(p.Amount)
+			if cur := l.totals[k.account][k.code]; cur != nil {
+				c := *cur
+				t = &c
+			}
+
+			staged[k] = t
+		}
+
+		if err := t.add(p.Side, p.Amount); err != nil {
+			return fmt.Errorf("%s: %s: %w", tx.ID, p.Account, err)
+		}
+
+		entries[i] = Entry{
+			TransactionID: tx.ID,
+			Time:          tx.Time,
+			Posting:       p,
+			Balance:       t.balance(l.accounts[p.Account].Type.Normal()),
+		}
+	}
+
+	for k, t := range staged {
+		if l.totals[k.account] == nil {
+			l.totals[k.account] = make(map[string]*total)
+		}
+
+		l.totals[k.account][k.code] = t
+	}
+
+	for i, p := range tx.Postings {
+		l.entries[p.Account] = append(l.entries[p.Account], entries[i])
+	}
+
+	l.transactions = append(l.transactions, tx)
+
+	return nil
+}
+
+// Transactions returns the posted transactions, in the order they were
+// posted.
+func (l *Ledger) Transactions() []Transaction {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	// Copy the postings too, so that the caller cannot rewrite the history.
+	res := make([]Transaction, len(l.transactions))
+	for i, tx := range l.transactions {
+		res[i] = tx
+		res[i].Postings = append([]Posting(nil), tx.Postings...)
+	}
+
+	return res
+}
+
+// Balance returns the balance of the account in the currency, positive on the
+// normal side of the account. The balance is signed, see money.Money.Signed.
+func (l *Ledger) Balance(account string, currency money.Currency) (*money.Money[int64], error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	a, ok := l.accounts[account]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, account)
+	}
+
+	t := l.totals[account][currency.Code]
+	if t == nil {
+		return money.NewMoneyWithCurrency(int64(0), currency).Signed(), nil
+	}
+
+	return t.balance(a.Type.Normal()), nil
+}
+
+// Entries returns the postings to the account with their running balances.
+func (l *Ledger) Entries(account string) ([]Entry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if _, ok := l.accounts[account]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, account)
+	}
+
+	res := make([]Entry, len(l.entries[account]))
+	copy(res, l.entries[account])
+
+	return res, nil
+}
+
+// TrialBalanceLine is the net balance of an account in a currency, on the
+// debit or the credit side.
+type TrialBalanceLine struct {
+	Account  Account
+	Currency money.Currency
+	Debit    *money.Money[int64]
+	Credit   *money.Money[int64]
+}
+
+// TrialBalance lists the balance of every account, and the debit and credit
+// totals for each currency, which are always equal.
+type TrialBalance struct {
+	Lines   []TrialBalanceLine
+	Debits  map[string]*money.Money[int64]
+	Credits map[string]*money.Money[int64]
+}
+
+// TrialBalance returns the balances of all accounts, sorted by account code
+// and currency.
+func (l *Ledger) TrialBalance() (*TrialBalance, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	tb := &TrialBalance{
+		Debits:  make(map[string]*money.Money[int64]),
+		Credits: make(map[string]*money.Money[int64]),
+	}
+
+	for code, byCurrency := range l.totals {
+		for currency, t := range byCurrency {
+			line := TrialBalanceLine{
+				Account:  l.accounts[code],
+				Currency: t.debit.Currency(),
+				Debit:    t.debit.WithAmount(0),
+				Credit:   t.credit.WithAmount(0),
+			}
+
+			if net := t.balance(Debit); net.Amount() >= 0 {
+				line.Debit = net
+			} else {
+				line.Credit = net.Neg()
+			}
+
+			if err := tb.add(currency, line); err != nil {
+				return nil, err
+			}
+
+			tb.Lines = append(tb.Lines, line)
+		}
+	}
+
+	sort.Slice(tb.Lines, func(i, j int) bool {
+		a, b := tb.Lines[i], tb.Lines[j]
+		if a.Account.Code != b.Account.Code {
+			return a.Account.Code < b.Account.Code
+		}
+
+		return a.Currency.Code < b.Currency.Code
+	})
+
+	for currency, debit := range tb.Debits {
+		if !debit.Equal(tb.Credits[currency]) {
+			return nil, fmt.Errorf("%w: %s debits %s, credits %s", ErrUnbalanced, currency, debit, tb.Credits[currency])
+		}
+	}
+
+	return tb, nil
+}
+
+func (tb *TrialBalance) add(currency string, line TrialBalanceLine) error {
+	debit, credit := tb.Debits[currency], tb.Credits[currency]
+	if debit == nil {
+		tb.Debits[currency], tb.Credits[currency] = line.Debit, line.Credit
+		return nil
+	}
+
+	debit, err := debit.Add(line.Debit)
+	if err != nil {
+		return err
+	}
+
+	credit, err = credit.Add(line.Credit)
+	if err != nil {
+		return err
+	}
+
+	tb.Debits[currency], tb.Credits[currency] = debit, credit
+
+	return nil
+}
+
+// total keeps the debits and credits in a single currency.
+type total struct {
+	debit  *money.Money[int64]
+	credit *money.Money[int64]
+}
+
+func newTotal(m *money.Money[int64]) *total {
+	return &total{
+		debit:  m.WithAmount(0),
+		credit: m.WithAmount(0),
+	}
+}
+
+// add adds the amount to the side, and leaves the total unchanged on error.
+func (t *total) add(side Side, m *money.Money[int64]) error {
+	switch side {
+	case Debit:
+		res, err := t.debit.Add(m)
+		if err != nil {
+			return err
+		}
+
+		t.debit = res
+	case Credit:
+		res, err := t.credit.Add(m)
+		if err != nil {
+			return err
+		}
+
+		t.credit = res
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidSide, int(side))
+	}
+
+	return nil
+}
+
+// balance returns the difference of both sides, positive on the given side.
+// The balance is signed, since an account may go below its normal side, e.g.
+// an overdrawn bank account.
+func (t *total) balance(normal Side) *money.Money[int64] {
+	if normal == Debit {
+		res, _ := t.debit.Sub(t.credit)
+		return res.Signed()
+	}
+
+	res, _ := t.credit.Sub(t.debit)
+	return res.Signed()
+}
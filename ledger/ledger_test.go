@@ -0,0 +1,249 @@
+package ledger_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/money"
+	"github.com/alextanhongpin/money/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func usd(dollars int64) *money.Money[int64] {
+	return money.USD.New(dollars, 0)
+}
+
+func newLedger(t *testing.T) *ledger.Ledger {
+	l := ledger.New()
+	for _, a := range []ledger.Account{
+		{Code: "1000", Name: "Cash", Type: ledger.Asset},
+		{Code: "2000", Name: "Payables", Type: ledger.Liability},
+		{Code: "3000", Name: "Capital", Type: ledger.Equity},
+		{Code: "4000", Name: "Sales", Type: ledger.Income},
+		{Code: "5000", Name: "Rent", Type: ledger.Expense},
+	} {
+		assert.Nil(t, l.Open(a))
+	}
+
+	return l
+}
+
+func TestLedger(t *testing.T) {
+	t.Run("post", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		assert.Nil(l.Post(ledger.Transaction{
+			ID:   "tx1",
+			Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: usd(1000)},
+				{Account: "3000", Side: ledger.Credit, Amount: usd(1000)},
+			},
+		}))
+		assert.Nil(l.Post(ledger.Transaction{
+			ID: "tx2",
+			Postings: []ledger.Posting{
+				{Account: "5000", Side: ledger.Debit, Amount: usd(300)},
+				{Account: "1000", Side: ledger.Credit, Amount: usd(200)},
+				{Account: "2000", Side: ledger.Credit, Amount: usd(100)},
+			},
+		}))
+
+		cash, err := l.Balance("1000", money.USD)
+		assert.Nil(err)
+		assert.True(usd(800).Equal(cash))
+
+		capital, err := l.Balance("3000", money.USD)
+		assert.Nil(err)
+		assert.True(usd(1000).Equal(capital))
+
+		sales, err := l.Balance("4000", money.USD)
+		assert.Nil(err)
+		assert.True(sales.IsZero())
+
+		entries, err := l.Entries("1000")
+		assert.Nil(err)
+		assert.Len(entries, 2)
+		assert.Equal("tx1", entries[0].TransactionID)
+		assert.True(usd(1000).Equal(entries[0].Balance))
+		assert.True(usd(800).Equal(entries[1].Balance))
+
+		assert.Len(l.Transactions(), 2)
+	})
+
+	t.Run("unbalanced", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		err := l.Post(ledger.Transaction{
+			ID: "tx1",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: usd(100)},
+				{Account: "3000", Side: ledger.Credit, Amount: usd(99)},
+			},
+		})
+		assert.True(errors.Is(err, ledger.ErrUnbalanced))
+		assert.Len(l.Transactions(), 0)
+	})
+
+	t.Run("balanced per currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		err := l.Post(ledger.Transaction{
+			ID: "tx1",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: usd(100)},
+				{Account: "3000", Side: ledger.Credit, Amount: money.SGD.New(100, 0)},
+			},
+		})
+		assert.True(errors.Is(err, ledger.ErrUnbalanced))
+
+		assert.Nil(l.Post(ledger.Transaction{
+			ID: "tx2",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: usd(100)},
+				{Account: "1000", Side: ledger.Debit, Amount: money.SGD.New(135, 0)},
+				{Account: "3000", Side: ledger.Credit, Amount: usd(100)},
+				{Account: "3000", Side: ledger.Credit, Amount: money.SGD.New(135, 0)},
+			},
+		}))
+
+		sgd, err := l.Balance("1000", money.SGD)
+		assert.Nil(err)
+		assert.True(money.SGD.New(135, 0).Equal(sgd))
+	})
+
+	t.Run("invalid postings", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		post := func(postings ...ledger.Posting) error {
+			return l.Post(ledger.Transaction{ID: "tx", Postings: postings})
+		}
+
+		assert.True(errors.Is(post(ledger.Posting{Account: "1000", Amount: usd(1)}), ledger.ErrNoPostings))
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Debit, Amount: usd(0)},
+			ledger.Posting{Account: "3000", Side: ledger.Credit, Amount: usd(0)},
+		), ledger.ErrInvalidAmount))
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Debit, Amount: usd(-1).Signed()},
+			ledger.Posting{Account: "3000", Side: ledger.Credit, Amount: usd(-1).Signed()},
+		), ledger.ErrInvalidAmount), "signed negative")
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Debit, Amount: usd(1)},
+			ledger.Posting{Account: "9999", Side: ledger.Credit, Amount: usd(1)},
+		), ledger.ErrUnknownAccount))
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Debit, Amount: money.SGD.WithUnit(5).New(0, 3)},
+			ledger.Posting{Account: "3000", Side: ledger.Credit, Amount: money.SGD.WithUnit(5).New(0, 3)},
+		), money.ErrFractionalAmount))
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Debit, Amount: money.SGD.WithUnit(5).New(1, 0)},
+			ledger.Posting{Account: "3000", Side: ledger.Credit, Amount: money.SGD.New(1, 0)},
+		), money.ErrUnitMismatch))
+		assert.True(errors.Is(post(
+			ledger.Posting{Account: "1000", Side: ledger.Side(9), Amount: usd(1)},
+			ledger.Posting{Account: "3000", Side: ledger.Credit, Amount: usd(1)},
+		), ledger.ErrInvalidSide))
+
+		assert.True(errors.Is(l.Open(ledger.Account{Code: "1000"}), ledger.ErrAccountExists))
+	})
+
+	t.Run("negative balance", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		assert.Nil(l.Post(ledger.Transaction{
+			ID: "tx1",
+			Postings: []ledger.Posting{
+				{Account: "5000", Side: ledger.Debit, Amount: usd(1)},
+				{Account: "1000", Side: ledger.Credit, Amount: usd(1)},
+			},
+		}))
+
+		cash, err := l.Balance("1000", money.USD)
+		assert.Nil(err)
+		assert.Equal(int64(-100), cash.Amount())
+		assert.True(cash.IsSigned())
+		assert.Nil(cash.Validate())
+		assert.Equal([]int64{-50, -50}, cash.Split(2))
+
+		entries, err := l.Entries("1000")
+		assert.Nil(err)
+		assert.Nil(entries[0].Balance.Validate())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := newLedger(t)
+		assert.Nil(l.Post(ledger.Transaction{
+			ID: "tx1",
+			Postings: []ledger.Posting{
+				{Account: "1000", Side: ledger.Debit, Amount: money.NewMoneyWithCurrency[int64](math.MaxInt64, money.USD)},
+				{Account: "3000", Side: ledger.Credit, Amount: money.NewMoneyWithCurrency[int64](math.MaxInt64, money.USD)},
+			},
+		}))
+
+		err := l.Post(ledger.Transaction{
+			ID: "tx2",
+			Postings: []ledger.Posting{
+				{Account: "5000", Side: ledger.Debit, Amount: usd(1)},
+				{Account: "1000", Side: ledger.Debit, Amount: usd(1)},
+				{Account: "3000", Side: ledger.Credit, Amount: usd(2)},
+			},
+		})
+		assert.True(errors.Is(err, money.ErrOverflow), err)
+
+		// The rejected transaction leaves no trace.
+		assert.Len(l.Transactions(), 1)
+
+		rent, err := l.Balance("5000", money.USD)
+		assert.Nil(err)
+		assert.True(rent.IsZero())
+
+		entries, err := l.Entries("5000")
+		assert.Nil(err)
+		assert.Len(entries, 0)
+
+		cash, err := l.Balance("1000", money.USD)
+		assert.Nil(err)
+		assert.Equal(int64(math.MaxInt64), cash.Amount())
+	})
+}
+
+func TestTrialBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newLedger(t)
+	assert.Nil(l.Post(ledger.Transaction{
+		ID: "tx1",
+		Postings: []ledger.Posting{
+			{Account: "1000", Side: ledger.Debit, Amount: usd(1000)},
+			{Account: "3000", Side: ledger.Credit, Amount: usd(1000)},
+		},
+	}))
+	assert.Nil(l.Post(ledger.Transaction{
+		ID: "tx2",
+		Postings: []ledger.Posting{
+			{Account: "1000", Side: ledger.Debit, Amount: usd(50)},
+			{Account: "4000", Side: ledger.Credit, Amount: usd(50)},
+		},
+	}))
+
+	tb, err := l.TrialBalance()
+	assert.Nil(err)
+	assert.Len(tb.Lines, 3)
+	assert.Equal("1000", tb.Lines[0].Account.Code)
+	assert.True(usd(1050).Equal(tb.Lines[0].Debit))
+	assert.True(tb.Lines[0].Credit.IsZero())
+	assert.Equal("4000", tb.Lines[2].Account.Code)
+	assert.True(usd(50).Equal(tb.Lines[2].Credit))
+	assert.True(usd(1050).Equal(tb.Debits["USD"]))
+	assert.True(usd(1050).Equal(tb.Credits["USD"]))
+}
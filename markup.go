@@ -0,0 +1,101 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Markup returns the price after adding the percent of the cost, e.g. a 25%
+// markup on a cost of 10.00 is 12.50. The markup is rounded half up to the
+// unit unless WithRounding is given. Markups above 100% require
+// WithUncappedPercent.
+func Markup[T constraints.Integer](cost *Money[T], percent Percenter, opts ...Option) (*Money[T], error) {
+	res, err := MarkupBig(cost.Big(), percent, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return MoneyFromBig[T](res)
+}
+
+// MarkupBig is like Markup, but for BigMoney.
+func MarkupBig(cost *BigMoney, percent Percenter, opts ...Option) (*BigMoney, error) {
+	return addPercent(cost, percent, RoundHalfUp, opts)
+}
+
+// Surcharge returns the amount after adding the percent as a fee, e.g. a
+// 2.9% card surcharge. The fee is rounded down to the unit unless
+// WithRounding is given, so that the customer is never overcharged.
+// Surcharges above 100% require WithUncappedPercent.
+func Surcharge[T constraints.Integer](m *Money[T], percent Percenter, opts ...Option) (*Money[T], error) {
+	res, err := SurchargeBig(m.Big(), percent, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return MoneyFromBig[T](res)
+}
+
+// SurchargeBig is like Surcharge, but for BigMoney.
+func SurchargeBig(m *BigMoney, percent Percenter, opts ...Option) (*BigMoney, error) {
+	return addPercent(m, percent, RoundDown, opts)
+}
+
+// PriceFromMargin returns the price that yields the gross margin on the cost,
+// that is cost / (1 - margin), e.g. a 40% margin on a cost of 6.00 is 10.00.
+// The price is rounded up to the unit unless WithRounding is given, so that
+// the margin is at least met. The margin must be below 100%.
+func PriceFromMargin[T constraints.Integer](cost *Money[T], margin Percenter, opts ...Option) (*Money[T], error) {
+	res, err := PriceFromMarginBig(cost.Big(), margin, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return MoneyFromBig[T](res)
+}
+
+// PriceFromMarginBig is like PriceFromMargin, but for BigMoney.
+func PriceFromMarginBig(cost *BigMoney, margin Percenter, opts ...Option) (*BigMoney, error) {
+	if err := cost.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundCeiling, optRounding, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p := margin.Percentage()
+	r := p.Rat()
+	if r.Sign() < 0 || r.Cmp(big.NewRat(1, 1)) >= 0 {
+		return nil, fmt.Errorf("%w: margin %s must be below 100%%", ErrPercentOutOfRange, p)
+	}
+
+	r.Sub(big.NewRat(1, 1), r)
+
+	return cost.WithAmount(quantize(cost.amount, r.Inv(r), cost.unit, o.rounding)), nil
+}
+
+// addPercent returns the amount plus the percent of it, rounded with the
+// mode unless WithRounding is given.
+func addPercent(m *BigMoney, percent Percenter, mode RoundingMode, opts []Option) (*BigMoney, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(mode, optRounding|optUncapped, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p := percent.Percentage()
+	if err := p.validate(o.uncapped); err != nil {
+		return nil, err
+	}
+
+	res := quantize(m.amount, p.Rat(), m.unit, o.rounding)
+
+	return m.WithAmount(res.Add(res, m.amount)), nil
+}
@@ -0,0 +1,110 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkup(t *testing.T) {
+	tests := []struct {
+		cost     int64
+		unit     int64
+		percent  money.Percentage
+		opts     []money.Option
+		expected int64
+		scenario string
+	}{
+		{cost: 1000, unit: 1, percent: money.NewPercentage(25, 1), expected: 1250, scenario: "25%"},
+		{cost: 1000, unit: 1, percent: money.NewPercentage(100, 3), expected: 1333, scenario: "rounds half up"},
+		{cost: 1005, unit: 1, percent: money.NewPercentage(10, 1), expected: 1106, scenario: "half rounds up"},
+		{cost: 1005, unit: 1, percent: money.NewPercentage(10, 1), opts: []money.Option{money.WithRounding(money.RoundHalfEven)}, expected: 1105, scenario: "half even"},
+		{cost: 1000, unit: 5, percent: money.NewPercentage(33, 1), expected: 1330, scenario: "unit 5"},
+		{cost: 1000, unit: 1, percent: money.NewPercentage(150, 1), opts: []money.Option{money.WithUncappedPercent()}, expected: 2500, scenario: "above 100%"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			res, err := money.Markup(money.NewMoney(test.cost, test.unit), test.percent, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, res.Amount())
+			assert.Equal(test.unit, res.Unit())
+
+			b, err := money.MarkupBig(money.NewBigMoney(big.NewInt(test.cost), big.NewInt(test.unit)), test.percent, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, b.Amount().Int64())
+		})
+	}
+
+	t.Run("above 100% without option", func(t *testing.T) {
+		_, err := money.Markup(money.NewMoney(1000, 1), money.NewPercentage(150, 1))
+		assert.True(t, errors.Is(err, money.ErrPercentOutOfRange))
+	})
+}
+
+func TestSurcharge(t *testing.T) {
+	assert := assert.New(t)
+
+	res, err := money.Surcharge(money.NewMoneyWithCurrency[int64](1999, money.USD), money.MustParsePercentage("2.9%"))
+	assert.Nil(err)
+	assert.Equal(int64(2056), res.Amount())
+	assert.Equal(money.USD, res.Currency())
+
+	res, err = money.Surcharge(money.NewMoney[int64](1999, 1), money.MustParsePercentage("2.9%"), money.WithRounding(money.RoundUp))
+	assert.Nil(err)
+	assert.Equal(int64(2057), res.Amount())
+
+	b, err := money.SurchargeBig(money.NewBigMoney(big.NewInt(1999), big.NewInt(1)), money.BasisPoints(290))
+	assert.Nil(err)
+	assert.Equal(int64(2056), b.Amount().Int64())
+
+	_, err = money.Surcharge(money.NewMoney(int8(100), 1), money.NewPercentage(50, 1))
+	assert.True(errors.Is(err, money.ErrOverflow))
+}
+
+func TestPriceFromMargin(t *testing.T) {
+	tests := []struct {
+		cost     int64
+		unit     int64
+		margin   money.Percentage
+		expected int64
+		scenario string
+	}{
+		{cost: 600, unit: 1, margin: money.NewPercentage(40, 1), expected: 1000, scenario: "exact"},
+		{cost: 1000, unit: 1, margin: money.NewPercentage(30, 1), expected: 1429, scenario: "rounds up"},
+		{cost: 1000, unit: 5, margin: money.NewPercentage(30, 1), expected: 1430, scenario: "unit 5"},
+		{cost: 1000, unit: 1, margin: money.Percentage{}, expected: 1000, scenario: "no margin"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			res, err := money.PriceFromMargin(money.NewMoney(test.cost, test.unit), test.margin)
+			assert.Nil(err)
+			assert.Equal(test.expected, res.Amount())
+
+			// The margin is at least met.
+			got := big.NewRat(res.Amount()-test.cost, res.Amount())
+			assert.True(got.Cmp(test.margin.Rat()) >= 0)
+
+			b, err := money.PriceFromMarginBig(money.NewBigMoney(big.NewInt(test.cost), big.NewInt(test.unit)), test.margin)
+			assert.Nil(err)
+			assert.Equal(test.expected, b.Amount().Int64())
+		})
+	}
+
+	t.Run("invalid margin", func(t *testing.T) {
+		assert := assert.New(t)
+
+		for _, margin := range []money.Percentage{money.NewPercentage(100, 1), money.NewPercentage(-1, 1)} {
+			_, err := money.PriceFromMargin(money.NewMoney(1000, 1), margin)
+			assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+		}
+	})
+}
@@ -1,20 +1,36 @@
 package money
 
 import (
+	"fmt"
 	"math/big"
 
 	"golang.org/x/exp/constraints"
 )
 
+// Sum returns the sum of ns. It panics if the sum does not fit in T, see
+// TrySum.
 func Sum[T constraints.Integer](ns []T) T {
-	var tot T
-	for i := 0; i < len(ns); i++ {
-		tot += ns[i]
+	tot, err := TrySum(ns)
+	if err != nil {
+		panic(err)
 	}
 
 	return tot
 }
 
+// TrySum is like Sum, but returns ErrOverflow if the sum does not fit in T.
+func TrySum[T constraints.Integer](ns []T) (T, error) {
+	var tot T
+	for _, n := range ns {
+		var overflow bool
+		if tot, overflow = addOverflows(tot, n); overflow {
+			return 0, fmt.Errorf("%w: sum of %v", ErrOverflow, ns)
+		}
+	}
+
+	return tot, nil
+}
+
 func SumBig(ns []*big.Int) *big.Int {
 	tot := big.NewInt(0)
 	for i := 0; i < len(ns); i++ {
@@ -3,6 +3,7 @@ package money
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"golang.org/x/exp/constraints"
@@ -10,14 +11,26 @@ import (
 )
 
 var (
-	ErrUnitInvalid      = errors.New("money: unit must be at least 1")
-	ErrNegativeAmount   = errors.New("money: negative amount")
-	ErrFractionalAmount = errors.New("money: amount cannot be fraction")
+	ErrUnitInvalid       = errors.New("money: unit must be at least 1")
+	ErrNegativeAmount    = errors.New("money: negative amount")
+	ErrFractionalAmount  = errors.New("money: amount cannot be fraction")
+	ErrCurrencyMismatch  = errors.New("money: currency mismatch")
+	ErrUnitMismatch      = errors.New("money: unit mismatch")
+	ErrRatioInvalid      = errors.New("money: ratios must be positive and sum to more than 0")
+	ErrInvalidSplit      = errors.New("money: invalid split")
+	ErrInvalidAllocation = errors.New("money: invalid allocation")
+	ErrStrategyInvalid   = errors.New("money: invalid allocation strategy")
+	ErrSpreadInvalid     = errors.New("money: invalid split spread")
+	ErrJSONShapeInvalid  = errors.New("money: invalid JSON shape")
+	ErrOptionInvalid     = errors.New("money: option does not apply")
+	ErrOverflow          = errors.New("money: amount overflows")
 )
 
 type Money[T constraints.Integer] struct {
-	amount T
-	unit   T
+	amount   T
+	unit     T
+	currency Currency
+	signed   bool
 }
 
 func (m *Money[T]) Validate() error {
@@ -25,7 +38,7 @@ func (m *Money[T]) Validate() error {
 		return fmt.Errorf("%w: %d", ErrUnitInvalid, m.unit)
 	}
 
-	if m.amount < 0 {
+	if m.amount < 0 && !m.signed {
 		return ErrNegativeAmount
 	}
 
@@ -43,6 +56,16 @@ func NewMoney[T constraints.Integer](amount, unit T) *Money[T] {
 	}
 }
 
+// NewMoneyWithCurrency returns Money in the currency, using the currency's
+// default smallest unit.
+func NewMoneyWithCurrency[T constraints.Integer](amount T, currency Currency) *Money[T] {
+	return &Money[T]{
+		amount:   amount,
+		unit:     T(currency.Unit),
+		currency: currency,
+	}
+}
+
 func (m *Money[T]) Amount() T {
 	return m.amount
 }
@@ -51,118 +74,613 @@ func (m *Money[T]) Unit() T {
 	return m.unit
 }
 
-func (m *Money[T]) Split(n uint) []T {
-	if err := m.Validate(); err != nil {
+func (m *Money[T]) Currency() Currency {
+	return m.currency
+}
+
+// WithAmount returns a new Money of the given amount, carrying over the unit,
+// currency and signed mode.
+func (m *Money[T]) WithAmount(amount T) *Money[T] {
+	return &Money[T]{
+		amount:   amount,
+		unit:     m.unit,
+		currency: m.currency,
+		signed:   m.signed,
+	}
+}
+
+// WithAmounts is like WithAmount, but for the results of Split and Allocate.
+func (m *Money[T]) WithAmounts(amounts []T) []*Money[T] {
+	res := make([]*Money[T], len(amounts))
+	for i, amt := range amounts {
+		res[i] = m.WithAmount(amt)
+	}
+
+	return res
+}
+
+// Split divides the amount into n parts, each rounded down to the unit unless
+// WithRounding is given, with the remainder going to the last part. See
+// WithSpread for spreading the remainder instead. It panics if the Money is
+// invalid, or n is more than math.MaxInt32, see TrySplit.
+func (m *Money[T]) Split(n uint, opts ...Option) []T {
+	res, err := m.TrySplit(n, opts...)
+	if err != nil {
 		panic(err)
 	}
 
+	return res
+}
+
+// TrySplit is like Split, but returns an error instead of panicking.
+func (m *Money[T]) TrySplit(n uint, opts ...Option) ([]T, error) {
+	return m.SplitInto(nil, n, opts...)
+}
+
+// SplitInto is like TrySplit, but writes the parts into dst, and returns
+// dst resized to n. It reuses the backing array of dst if it has the
+// capacity, and then does not allocate, unless the spread is SpreadShuffle or
+// the options are created within the loop.
+func (m *Money[T]) SplitInto(dst []T, n uint, opts ...Option) ([]T, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundFloor, optRounding|optSpread, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkParts(n); err != nil {
+		return nil, err
+	}
+
+	dst = resize(dst, int(n))
 	if n == 0 {
-		return make([]T, 0)
+		return dst, nil
 	}
 
-	amt := m.amount / m.unit / T(n) * m.unit
-	if amt < 0 {
-		panic("money: negative amount")
+	// Negative amounts mirror the absolute amount.
+	amount, err := m.abs()
+	if err != nil {
+		return nil, err
 	}
 
-	res := make([]T, n)
-	for i := 0; i < int(n)-1; i++ {
-		res[i] = amt
+	if o.spread != SpreadNone {
+		splitSpread(dst, amount, m.unit, o)
+	} else {
+		// n may not fit in T, so divide in uint64. The share is at most the
+		// amount, which fits.
+		amt := T(roundQuo(uint64(amount/m.unit), uint64(n), o.rounding)) * m.unit
+
+		total := amount
+		for i := 0; i < int(n)-1; i++ {
+			share := amt
+			if share > total {
+				share = total
+			}
+
+			dst[i] = share
+			total -= share
+		}
+
+		dst[n-1] = total
+
+		if sum, err := TrySum(dst); err != nil || sum != amount {
+			return nil, fmt.Errorf("%w: %d by %d", ErrInvalidSplit, m.amount, n)
+		}
 	}
 
-	last := m.amount - amt*T(n-1)
-	if last < 0 {
-		panic("money: negative amount")
+	if m.amount < 0 {
+		negate(dst)
+	}
+
+	return dst, nil
+}
+
+func splitSpread[T constraints.Integer](dst []T, amount, unit T, o options) {
+	n := uint64(len(dst))
+	units := uint64(amount / unit)
+	per := T(units/n) * unit
+	left := int(units % n)
+
+	for i := range dst {
+		dst[i] = per
 	}
 
-	res[len(res)-1] = last
+	// SpreadFirst and SpreadLast are inlined, since spreadExtra allocates.
+	switch o.spread {
+	case SpreadFirst:
+		for i := 0; i < left; i++ {
+			dst[i] += unit
+		}
+	case SpreadLast:
+		for i := len(dst) - left; i < len(dst); i++ {
+			dst[i] += unit
+		}
+	default:
+		extra := spreadExtra(len(dst), left, o)
+		for i := range dst {
+			if extra(i) {
+				dst[i] += unit
+			}
+		}
+	}
+}
 
-	if m.amount != Sum(res) {
-		panic("money: invalid split")
+// Allocate divides the amount by the ratios, each rounded down to the unit
+// unless WithRounding is given, with the remainder going to the last ratio.
+// See WithStrategy for other ways of distributing the remainder. It panics if
+// the Money is invalid, see TryAllocate.
+func (m *Money[T]) Allocate(ratios []T, opts ...Option) []T {
+	res, err := m.TryAllocate(ratios, opts...)
+	if err != nil {
+		panic(err)
 	}
 
 	return res
 }
 
-func (m *Money[T]) Allocate(ratios []T) []T {
+// TryAllocate is like Allocate, but returns an error instead of panicking.
+func (m *Money[T]) TryAllocate(ratios []T, opts ...Option) ([]T, error) {
+	return m.AllocateInto(nil, ratios, opts...)
+}
+
+// AllocateInto is like TryAllocate, but writes the shares into dst, and
+// returns dst resized to the number of ratios. It reuses the backing array of
+// dst if it has the capacity, and then does not allocate, unless the values
+// do not fit in 64 bits or the options are created within the loop. dst must
+// not overlap the ratios.
+func (m *Money[T]) AllocateInto(dst, ratios []T, opts ...Option) ([]T, error) {
 	if err := m.Validate(); err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	o, err := newOptions(RoundFloor, optRounding|optStrategy, opts)
+	if err != nil {
+		return nil, err
 	}
 
+	dst = resize(dst, len(ratios))
 	if len(ratios) == 0 {
-		return make([]T, 0)
+		return dst, nil
+	}
+
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("%w: %v", ErrRatioInvalid, ratios)
+		}
 	}
 
-	units := m.amount / m.unit
+	// A sum that overflows a uint64 is never zero. All-zero ratios leave the
+	// whole amount to the last share.
+	fastTotal, fast := sumUint64(ratios)
+	if fast && fastTotal == 0 {
+		for i := range dst {
+			dst[i] = 0
+		}
+		dst[len(dst)-1] = m.amount
 
-	totalRatios := Sum(ratios)
+		return dst, nil
+	}
 
-	n := len(ratios)
-	res := make([]T, n)
+	// Negative amounts mirror the absolute amount.
+	amount, err := m.abs()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case o.strategy == LargestRemainder:
+		if !fast || !allocateLargestRemainderFast(dst, amount, m.unit, ratios, fastTotal) {
+			copy(dst, allocateLargestRemainder(amount, m.unit, ratios))
+		}
+	case !fast || !allocateFast(dst, amount, m.unit, ratios, fastTotal, o.rounding):
+		if err := allocate(dst, amount, m.unit, ratios, o.rounding); err != nil {
+			return nil, fmt.Errorf("%w: %d by %v", err, m.amount, ratios)
+		}
+	}
+
+	if m.amount < 0 {
+		negate(dst)
+	}
+
+	return dst, nil
+}
+
+// allocate is the math/big path of AllocateInto with RemainderLast, for
+// non-negative amounts.
+func allocate[T constraints.Integer](dst []T, amount, unit T, ratios []T, mode RoundingMode) error {
+	// The ratios may not sum up within T, so sum them as big.Int.
+	totalRatios := new(big.Int)
+	for _, r := range ratios {
+		totalRatios.Add(totalRatios, bigIntFromInteger(r))
+	}
+
+	units := amount / unit
 
-	total := m.amount
+	n := len(ratios)
+	total := amount
 	for i := 0; i < n-1; i++ {
 		if ratios[i] == 0 {
 			continue
 		}
 
-		ratio := divBigRatUint64(uint64(ratios[i]), uint64(totalRatios))
+		ratio := new(big.Rat).SetFrac(bigIntFromUint64(uint64(ratios[i])), totalRatios)
 		ratio.Mul(ratio, bigRatFromUint64(uint64(units)))
 
-		i64 := floor(ratio)
-		i64.Mul(i64, bigIntFromUint64(uint64(m.unit)))
-		u64 := i64.Uint64()
+		i64 := round(ratio, mode)
+		i64.Mul(i64, bigIntFromUint64(uint64(unit)))
+		share := T(i64.Uint64())
+		if share > total {
+			share = total
+		}
 
-		res[i] = T(u64)
-		total -= T(u64)
+		dst[i] = share
+		total -= share
 	}
 
-	res[n-1] = total
+	if total < 0 {
+		return ErrInvalidAllocation
+	}
+
+	dst[n-1] = total
+
+	return nil
+}
+
+// Discount returns the discounted amount, rounded up to the unit unless
+// WithRounding is given. It panics if the Money or percent is invalid, see
+// TryDiscount. For fractions, e.g. 12.5%, use DiscountPercentage.
+func (m *Money[T]) Discount(percent Percent, opts ...Option) T {
+	return m.DiscountPercentage(percent, opts...)
+}
+
+// TryDiscount is like Discount, but returns an error instead of panicking.
+func (m *Money[T]) TryDiscount(percent Percent, opts ...Option) (T, error) {
+	return m.TryDiscountPercentage(percent, opts...)
+}
+
+// DiscountPercentage is like Discount, but the percent is either a Percent or
+// a Percentage, and must be between 0 and 100 unless WithUncappedPercent is
+// given. It panics if the Money or percent is invalid, see
+// TryDiscountPercentage.
+func (m *Money[T]) DiscountPercentage(percent Percenter, opts ...Option) T {
+	res, err := m.TryDiscountPercentage(percent, opts...)
+	if err != nil {
+		panic(err)
+	}
 
 	return res
 }
 
-func (m *Money[T]) Discount(percent Percent) T {
+// TryDiscountPercentage is like DiscountPercentage, but returns an error
+// instead of panicking.
+func (m *Money[T]) TryDiscountPercentage(percent Percenter, opts ...Option) (T, error) {
 	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	if res, ok := m.discountFast(percent, opts); ok {
+		return res, nil
+	}
+
+	d, err := discount(bigIntFromInteger(m.amount), bigIntFromInteger(m.unit), percent, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	res, ok := integerFromBigInt[T](d)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, d)
+	}
+
+	return res, nil
+}
+
+// discountFast is TryDiscountPercentage without math/big, and reports false when the
+// values do not fit, or are invalid, so that discount reports the error.
+func (m *Money[T]) discountFast(percent Percenter, opts []Option) (T, bool) {
+	o, err := newOptions(RoundCeiling, optRounding|optUncapped, opts)
+	if err != nil {
+		return 0, false
+	}
+
+	num, den, ok := percentFrac(percent)
+	if !ok || (num > den && !o.uncapped) {
+		return 0, false
+	}
+
+	return discountFast(m.amount, m.unit, num, den, o.rounding)
+}
+
+func discount(amount, unit *big.Int, percent Percenter, opts []Option) (*big.Int, error) {
+	o, err := newOptions(RoundCeiling, optRounding|optUncapped, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p := percent.Percentage()
+	if err := p.validate(o.uncapped); err != nil {
+		return nil, err
+	}
+
+	return quantize(amount, p.Rat(), unit, o.rounding), nil
+}
+
+// Add returns the sum of both amounts, or ErrOverflow if it does not fit in
+// T, see Big.
+func (m *Money[T]) Add(o *Money[T]) (*Money[T], error) {
+	if err := m.compatible(o); err != nil {
+		return nil, err
+	}
+
+	res, overflow := addOverflows(m.amount, o.amount)
+	if overflow {
+		return nil, fmt.Errorf("%w: %d + %d", ErrOverflow, m.amount, o.amount)
+	}
+
+	return m.WithAmount(res), nil
+}
+
+// Sub returns the difference of both amounts, or ErrOverflow if it does not
+// fit in T, see Big.
+func (m *Money[T]) Sub(o *Money[T]) (*Money[T], error) {
+	if err := m.compatible(o); err != nil {
+		return nil, err
+	}
+
+	res, overflow := subOverflows(m.amount, o.amount)
+	if overflow {
+		return nil, fmt.Errorf("%w: %d - %d", ErrOverflow, m.amount, o.amount)
+	}
+
+	return m.WithAmount(res), nil
+}
+
+// MulInt returns the amount multiplied by n. It panics if the result does not
+// fit in T, see TryMulInt.
+func (m *Money[T]) MulInt(n T) *Money[T] {
+	res, err := m.TryMulInt(n)
+	if err != nil {
 		panic(err)
 	}
 
-	if err := percent.Validate(); err != nil {
+	return res
+}
+
+// TryMulInt is like MulInt, but returns an error instead of panicking.
+func (m *Money[T]) TryMulInt(n T) (*Money[T], error) {
+	res, overflow := mulOverflows(m.amount, n)
+	if overflow {
+		return nil, fmt.Errorf("%w: %d * %d", ErrOverflow, m.amount, n)
+	}
+
+	return m.WithAmount(res), nil
+}
+
+// Neg returns the negated amount. Note that Validate rejects negative
+// amounts unless the Money is Signed. It panics if the result does not fit in
+// T, e.g. for unsigned T, see TryNeg.
+func (m *Money[T]) Neg() *Money[T] {
+	res, err := m.TryNeg()
+	if err != nil {
 		panic(err)
 	}
 
-	units := m.amount / m.unit
+	return res
+}
+
+// TryNeg is like Neg, but returns an error instead of panicking.
+func (m *Money[T]) TryNeg() (*Money[T], error) {
+	res, overflow := negOverflows(m.amount)
+	if overflow {
+		return nil, fmt.Errorf("%w: -%d", ErrOverflow, m.amount)
+	}
+
+	return m.WithAmount(res), nil
+}
+
+// Cmp compares both amounts, and returns -1, 0 or +1 like big.Int.Cmp.
+func (m *Money[T]) Cmp(o *Money[T]) (int, error) {
+	if err := m.compatible(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
 
-	r64 := divBigRatUint64(uint64(percent), 100)
-	r64.Mul(r64, bigRatFromUint64(uint64(units)))
+// Equal returns true if both have the same amount, unit and currency.
+func (m *Money[T]) Equal(o *Money[T]) bool {
+	return m.compatible(o) == nil && m.amount == o.amount
+}
 
-	i64 := ceil(r64)
-	i64.Mul(i64, bigIntFromUint64(uint64(m.unit)))
+func (m *Money[T]) IsZero() bool {
+	return m.amount == 0
+}
 
-	return T(i64.Uint64())
+// Min returns the smaller of both.
+func (m *Money[T]) Min(o *Money[T]) (*Money[T], error) {
+	cmp, err := m.Cmp(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmp > 0 {
+		return o.WithAmount(o.amount), nil
+	}
+
+	return m.WithAmount(m.amount), nil
 }
 
-func AllocateMap[T constraints.Ordered, V constraints.Integer](m *Money[V], ratioByKey map[T]V) map[T]V {
-	keys := make([]T, 0, len(ratioByKey))
-	for k := range ratioByKey {
-		keys = append(keys, k)
+// Max returns the larger of both.
+func (m *Money[T]) Max(o *Money[T]) (*Money[T], error) {
+	cmp, err := m.Cmp(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmp < 0 {
+		return o.WithAmount(o.amount), nil
+	}
+
+	return m.WithAmount(m.amount), nil
+}
+
+func (m *Money[T]) compatible(o *Money[T]) error {
+	if !m.currency.Equal(o.currency) {
+		return fmt.Errorf("%w: %q and %q", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+
+	if m.unit != o.unit {
+		return fmt.Errorf("%w: %d and %d", ErrUnitMismatch, m.unit, o.unit)
 	}
-	slices.Sort(keys)
 
-	ratios := make([]V, len(ratioByKey))
-	for i, k := range keys {
-		ratios[i] = ratioByKey[k]
+	return nil
+}
+
+// Big returns the Money as BigMoney, e.g. to continue when a result
+// overflows T. See MoneyFromBig for the way back.
+func (m *Money[T]) Big() *BigMoney {
+	return &BigMoney{
+		amount:   bigIntFromInteger(m.amount),
+		unit:     bigIntFromInteger(m.unit),
+		currency: m.currency,
+		signed:   m.signed,
+	}
+}
+
+// MoneyFromBig converts the BigMoney to Money, or returns ErrOverflow if the
+// amount or unit does not fit in T.
+func MoneyFromBig[T constraints.Integer](b *BigMoney) (*Money[T], error) {
+	amount, ok := integerFromBigInt[T](b.amount)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOverflow, b.amount)
 	}
 
-	allocations := m.Allocate(ratios)
-	res := make(map[T]V)
-	for i, k := range keys {
-		res[k] = allocations[i]
+	unit, ok := integerFromBigInt[T](b.unit)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOverflow, b.unit)
+	}
+
+	return &Money[T]{
+		amount:   amount,
+		unit:     unit,
+		currency: b.currency,
+		signed:   b.signed,
+	}, nil
+}
+
+// moneysFromBig converts every BigMoney to Money[T], see MoneyFromBig.
+func moneysFromBig[T constraints.Integer](bs []*BigMoney) ([]*Money[T], error) {
+	res := make([]*Money[T], len(bs))
+	for i, b := range bs {
+		m, err := MoneyFromBig[T](b)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = m
+	}
+
+	return res, nil
+}
+
+// AllocateMap allocates the amount by the ratio of each key, in the order of
+// the keys. It panics like Allocate, see MapAllocator for reusing the
+// buffers.
+func AllocateMap[T constraints.Ordered, V constraints.Integer](m *Money[V], ratioByKey map[T]V, opts ...Option) map[T]V {
+	res := make(map[T]V, len(ratioByKey))
+
+	var a MapAllocator[T, V]
+	if err := a.AllocateInto(res, m, ratioByKey, opts...); err != nil {
+		panic(err)
 	}
 
 	return res
 }
 
+// MapAllocator is like AllocateMap, but reuses its buffers between calls.
+// The zero value is ready to use. A MapAllocator must not be used
+// concurrently.
+type MapAllocator[T constraints.Ordered, V constraints.Integer] struct {
+	keys   []T
+	ratios []V
+	shares []V
+}
+
+// AllocateInto allocates the amount by the ratio of each key like
+// AllocateMap, and writes the shares into dst, deleting the keys that have no
+// ratio. Once the buffers and dst have grown to fit the keys, it does not
+// allocate, see Money.AllocateInto.
+func (a *MapAllocator[T, V]) AllocateInto(dst map[T]V, m *Money[V], ratioByKey map[T]V, opts ...Option) error {
+	a.keys = a.keys[:0]
+	for k := range ratioByKey {
+		a.keys = append(a.keys, k)
+	}
+	slices.Sort(a.keys)
+
+	a.ratios = a.ratios[:0]
+	for _, k := range a.keys {
+		a.ratios = append(a.ratios, ratioByKey[k])
+	}
+
+	if a.shares == nil {
+		a.shares = make([]V, 0, len(a.ratios))
+	}
+
+	shares, err := m.AllocateInto(a.shares, a.ratios, opts...)
+	if err != nil {
+		return err
+	}
+	a.shares = shares
+
+	for k := range dst {
+		if _, ok := ratioByKey[k]; !ok {
+			delete(dst, k)
+		}
+	}
+
+	for i, k := range a.keys {
+		dst[k] = shares[i]
+	}
+
+	return nil
+}
+
+// maxParts is the most parts that Split returns, far more than fit in
+// memory, so that n is checked before the parts are allocated.
+const maxParts = math.MaxInt32
+
+func checkParts(n uint) error {
+	if n > maxParts {
+		return fmt.Errorf("%w: %d parts is more than %d", ErrInvalidSplit, n, maxParts)
+	}
+
+	return nil
+}
+
+// resize returns dst with n zeroed elements, reusing its backing array if it
+// has the capacity. A nil dst is always allocated.
+func resize[T constraints.Integer](dst []T, n int) []T {
+	if dst == nil || cap(dst) < n {
+		return make([]T, n)
+	}
+
+	dst = dst[:n]
+	for i := range dst {
+		dst[i] = 0
+	}
+
+	return dst
+}
+
 func divBigRat(a, b *big.Rat) *big.Rat {
 	return new(big.Rat).Mul(a, new(big.Rat).Inv(b))
 }
@@ -182,6 +700,14 @@ func bigRatFromBigInt(a, b *big.Int) *big.Rat {
 	return new(big.Rat).SetFrac(a, b)
 }
 
+func bigIntFromInteger[T constraints.Integer](n T) *big.Int {
+	if n < 0 {
+		return big.NewInt(int64(n))
+	}
+
+	return bigIntFromUint64(uint64(n))
+}
+
 func bigIntFromUint64(n uint64) *big.Int {
 	return new(big.Int).SetUint64(n)
 }
@@ -189,19 +715,3 @@ func bigIntFromUint64(n uint64) *big.Int {
 func bigRatFromUint64(n uint64) *big.Rat {
 	return new(big.Rat).SetUint64(n)
 }
-
-// https://golang-nuts.narkive.com/RQ5Nof2y/big-rat-ceil
-func ceil(x *big.Rat) *big.Int {
-	z := new(big.Int)
-	z.Add(x.Num(), x.Denom())
-	z.Sub(z, big.NewInt(1))
-	z.Div(z, x.Denom())
-	return z
-}
-
-// Returns a new big.Int set to the floor of x.
-func floor(x *big.Rat) *big.Int {
-	z := new(big.Int)
-	z.Div(x.Num(), x.Denom())
-	return z
-}
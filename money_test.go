@@ -11,7 +11,7 @@ import (
 	"golang.org/x/exp/rand"
 )
 
-func ExampleSplit() {
+func ExampleMoney_Split() {
 	m := money.NewMoney(5030, 1)
 	s := m.Split(3)
 
@@ -19,7 +19,7 @@ func ExampleSplit() {
 	// Output: [1676 1676 1678] 5030
 }
 
-func ExampleAllocate() {
+func ExampleMoney_Allocate() {
 	m := money.NewMoney(5030, 1)
 	a := m.Allocate([]int{1, 2, 5})
 
@@ -27,7 +27,7 @@ func ExampleAllocate() {
 	// Output: [628 1257 3145] 5030
 }
 
-func ExampleAllocateMapIntKey() {
+func ExampleAllocateMap_intKey() {
 	m := money.NewMoney(5030, 1)
 	a := money.AllocateMap(m, map[int64]int{
 		1000: 1,
@@ -44,7 +44,7 @@ func ExampleAllocateMapIntKey() {
 	// Output: map[1000:628 2000:1257 3000:3145] 5030
 }
 
-func ExampleAllocateMapStrKey() {
+func ExampleAllocateMap_strKey() {
 	m := money.NewMoney(5030, 1)
 	a := money.AllocateMap(m, map[string]int{
 		"a": 5,
@@ -294,3 +294,126 @@ func FuzzDiscount(f *testing.F) {
 		}
 	})
 }
+
+func TestMoneyArithmetic(t *testing.T) {
+	usd := func(amount int64) *money.Money[int64] {
+		return money.NewMoneyWithCurrency(amount, money.USD)
+	}
+
+	t.Run("add and sub", func(t *testing.T) {
+		assert := assert.New(t)
+
+		sum, err := usd(100).Add(usd(50))
+		assert.Nil(err)
+		assert.True(sum.Equal(usd(150)))
+
+		diff, err := usd(100).Sub(usd(30))
+		assert.Nil(err)
+		assert.True(diff.Equal(usd(70)))
+	})
+
+	t.Run("mul and neg", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.True(usd(25).MulInt(4).Equal(usd(100)))
+		assert.Equal(int64(-25), usd(25).Neg().Amount())
+		assert.True(usd(0).IsZero())
+		assert.False(usd(1).IsZero())
+	})
+
+	t.Run("cmp, min and max", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cmp, err := usd(1).Cmp(usd(2))
+		assert.Nil(err)
+		assert.Equal(-1, cmp)
+
+		lo, err := usd(1).Min(usd(2))
+		assert.Nil(err)
+		assert.True(lo.Equal(usd(1)))
+
+		hi, err := usd(1).Max(usd(2))
+		assert.Nil(err)
+		assert.True(hi.Equal(usd(2)))
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := usd(1).Add(money.NewMoneyWithCurrency(int64(1), money.SGD))
+		assert.True(errors.Is(err, money.ErrCurrencyMismatch))
+		assert.False(usd(1).Equal(money.NewMoneyWithCurrency(int64(1), money.SGD)))
+	})
+
+	t.Run("unit mismatch", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := usd(10).Cmp(money.NewMoneyWithCurrency(int64(10), money.USD.WithUnit(5)))
+		assert.True(errors.Is(err, money.ErrUnitMismatch))
+	})
+}
+
+func TestMoneyTry(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoney(100, 5)
+		s, err := m.TrySplit(3)
+		assert.Nil(err)
+		assert.Equal([]int{30, 30, 40}, s)
+
+		a, err := m.TryAllocate([]int{1, 2, 3})
+		assert.Nil(err)
+		assert.Equal([]int{15, 30, 55}, a)
+
+		d, err := m.TryDiscount(money.Percent(11))
+		assert.Nil(err)
+		assert.Equal(15, d)
+	})
+
+	t.Run("invalid money", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewMoney(-1, 1).TrySplit(3)
+		assert.True(errors.Is(err, money.ErrNegativeAmount))
+
+		_, err = money.NewMoney(3, 2).TryAllocate([]int{1, 1})
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+
+		_, err = money.NewMoney(3, 0).TryDiscount(money.Percent(5))
+		assert.True(errors.Is(err, money.ErrUnitInvalid))
+	})
+
+	t.Run("invalid percent", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewMoney(100, 1).TryDiscount(money.Percent(101))
+		assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+	})
+
+	t.Run("zero ratios", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.Equal([]int{100}, money.NewMoney(100, 1).Allocate([]int{0}))
+		assert.Equal([]int{0, 0, 100}, money.NewMoney(100, 1).Allocate([]int{0, 0, 0}))
+		assert.Equal([]int{0, -100}, money.NewMoney(-100, 1).Signed().Allocate([]int{0, 0}, money.WithStrategy(money.LargestRemainder)))
+
+		res := money.NewBigMoney(big.NewInt(100), big.NewInt(1)).Allocate([]uint64{0, 0})
+		assert.Equal([]*big.Int{big.NewInt(0), big.NewInt(100)}, res)
+	})
+
+	t.Run("invalid ratios", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewMoney(100, 1).TryAllocate([]int{2, -1})
+		assert.True(errors.Is(err, money.ErrRatioInvalid))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.Panics(func() {
+			money.NewMoney(-1, 1).Split(3)
+		})
+	})
+}
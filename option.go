@@ -0,0 +1,109 @@
+package money
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Option configures Split, Allocate, Discount and the other operations that
+// round. An operation given an option that does not apply to it, e.g.
+// WithSpread to Allocate, returns ErrOptionInvalid.
+type Option func(*options)
+
+type options struct {
+	rounding RoundingMode
+	strategy Strategy
+	spread   Spread
+	seed     int64
+	uncapped bool
+
+	workers   int
+	batchSize int
+
+	// set are the options given.
+	set optionSet
+}
+
+// optionSet is a set of options, by the function that sets them.
+type optionSet uint
+
+const (
+	optRounding optionSet = 1 << iota
+	optStrategy
+	optSpread
+	optUncapped
+	optWorkers
+	optBatchSize
+)
+
+var optionNames = []struct {
+	opt  optionSet
+	name string
+}{
+	{optRounding, "WithRounding"},
+	{optStrategy, "WithStrategy"},
+	{optSpread, "WithSpread"},
+	{optUncapped, "WithUncappedPercent"},
+	{optWorkers, "WithWorkers"},
+	{optBatchSize, "WithBatchSize"},
+}
+
+// WithRounding sets the rounding mode for each share. Split and Allocate
+// round down by default, and Discount rounds up.
+//
+// Rounding up may leave the last share with less than the others, or with
+// nothing, since the shares always sum up to the amount.
+func WithRounding(mode RoundingMode) Option {
+	return func(o *options) {
+		o.rounding = mode
+		o.set |= optRounding
+	}
+}
+
+var optionsPool = sync.Pool{
+	New: func() any {
+		return new(options)
+	},
+}
+
+// newOptions applies the options to the defaults, and returns
+// ErrOptionInvalid for options that are not allowed.
+func newOptions(rounding RoundingMode, allowed optionSet, opts []Option) (options, error) {
+	o := options{
+		rounding: rounding,
+	}
+	if len(opts) > 0 {
+		// The options leak the pointer they are given, so apply them to a
+		// pooled copy rather than moving o to the heap on every call.
+		p := optionsPool.Get().(*options)
+		*p = o
+		for _, opt := range opts {
+			opt(p)
+		}
+
+		o = *p
+		optionsPool.Put(p)
+	}
+
+	if invalid := o.set &^ allowed; invalid != 0 {
+		for _, n := range optionNames {
+			if invalid&n.opt != 0 {
+				return o, fmt.Errorf("%w: %s", ErrOptionInvalid, n.name)
+			}
+		}
+	}
+
+	if err := o.rounding.Validate(); err != nil {
+		return o, err
+	}
+
+	if err := o.strategy.Validate(); err != nil {
+		return o, err
+	}
+
+	if err := o.spread.Validate(); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
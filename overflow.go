@@ -0,0 +1,49 @@
+package money
+
+import "golang.org/x/exp/constraints"
+
+// The helpers below report whether the operation overflows T, for both
+// signed and unsigned integers of any width.
+
+func isSigned[T constraints.Integer]() bool {
+	return ^T(0) < 0
+}
+
+// isMin reports whether n is the smallest value of a signed T, which has no
+// positive counterpart.
+func isMin[T constraints.Integer](n T) bool {
+	return n < 0 && -n == n
+}
+
+func addOverflows[T constraints.Integer](a, b T) (T, bool) {
+	c := a + b
+	return c, (b > 0 && c < a) || (b < 0 && c > a)
+}
+
+func subOverflows[T constraints.Integer](a, b T) (T, bool) {
+	c := a - b
+	return c, (b > 0 && c > a) || (b < 0 && c < a)
+}
+
+func mulOverflows[T constraints.Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+
+	// The smallest signed value times -1 wraps to itself, and so does the
+	// division that would catch it.
+	if isSigned[T]() && ((a == ^T(0) && isMin(b)) || (b == ^T(0) && isMin(a))) {
+		return a * b, true
+	}
+
+	c := a * b
+	return c, c/b != a
+}
+
+func negOverflows[T constraints.Integer](a T) (T, bool) {
+	if a == 0 {
+		return 0, false
+	}
+
+	return -a, !isSigned[T]() || isMin(a)
+}
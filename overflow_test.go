@@ -0,0 +1,185 @@
+package money_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverflowInt8(t *testing.T) {
+	fits := func(n int) bool {
+		return n >= math.MinInt8 && n <= math.MaxInt8
+	}
+
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		m := money.NewMoney(int8(a), 1).Signed()
+
+		neg, err := m.TryNeg()
+		if fits(-a) {
+			assert.Nil(t, err)
+			assert.Equal(t, int8(-a), neg.Amount())
+		} else {
+			assert.True(t, errors.Is(err, money.ErrOverflow), "-%d", a)
+		}
+
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			o := money.NewMoney(int8(b), 1)
+
+			sum, err := m.Add(o)
+			if fits(a + b) {
+				assert.Equal(t, int8(a+b), sum.Amount())
+			} else {
+				assert.True(t, errors.Is(err, money.ErrOverflow), "%d + %d", a, b)
+			}
+
+			diff, err := m.Sub(o)
+			if fits(a - b) {
+				assert.Equal(t, int8(a-b), diff.Amount())
+			} else {
+				assert.True(t, errors.Is(err, money.ErrOverflow), "%d - %d", a, b)
+			}
+
+			prod, err := m.TryMulInt(int8(b))
+			if fits(a * b) {
+				assert.Equal(t, int8(a*b), prod.Amount())
+			} else {
+				assert.True(t, errors.Is(err, money.ErrOverflow), "%d * %d", a, b)
+			}
+		}
+	}
+}
+
+func TestOverflowUint8(t *testing.T) {
+	fits := func(n int) bool {
+		return n >= 0 && n <= math.MaxUint8
+	}
+
+	for a := 0; a <= math.MaxUint8; a++ {
+		m := money.NewMoney(uint8(a), 1)
+
+		_, err := m.TryNeg()
+		assert.Equal(t, a != 0, errors.Is(err, money.ErrOverflow), "-%d", a)
+
+		for b := 0; b <= math.MaxUint8; b++ {
+			o := money.NewMoney(uint8(b), 1)
+
+			_, err := m.Add(o)
+			assert.Equal(t, !fits(a+b), errors.Is(err, money.ErrOverflow), "%d + %d", a, b)
+
+			_, err = m.Sub(o)
+			assert.Equal(t, !fits(a-b), errors.Is(err, money.ErrOverflow), "%d - %d", a, b)
+
+			_, err = m.TryMulInt(uint8(b))
+			assert.Equal(t, !fits(a*b), errors.Is(err, money.ErrOverflow), "%d * %d", a, b)
+		}
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	t.Run("panics", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.Panics(func() {
+			money.NewMoney(int16(200), 1).MulInt(200)
+		})
+
+		assert.Panics(func() {
+			money.NewMoney(uint(1), 1).Neg()
+		})
+
+		assert.Panics(func() {
+			money.NewMoney(int8(math.MinInt8), 1).Signed().Abs()
+		})
+	})
+
+	t.Run("sum", func(t *testing.T) {
+		assert := assert.New(t)
+
+		sum, err := money.TrySum([]int8{100, 27})
+		assert.Nil(err)
+		assert.Equal(int8(127), sum)
+
+		_, err = money.TrySum([]int8{100, 28})
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		_, err = money.TrySum([]uint32{math.MaxUint32, 1})
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		assert.Equal(int8(127), money.Sum([]int8{100, 27}))
+		assert.Panics(func() {
+			money.Sum([]int8{100, 28})
+		})
+	})
+
+	t.Run("split into more parts than fit in memory", func(t *testing.T) {
+		assert := assert.New(t)
+
+		for _, n := range []uint{^uint(0), math.MaxInt32 + 1} {
+			_, err := money.NewMoney[int64](100, 1).TrySplit(n)
+			assert.True(errors.Is(err, money.ErrInvalidSplit), n)
+
+			_, err = money.NewBigMoney(big.NewInt(100), big.NewInt(1)).TrySplit(n)
+			assert.True(errors.Is(err, money.ErrInvalidSplit), n)
+		}
+	})
+
+	t.Run("split into more parts than T holds", func(t *testing.T) {
+		assert := assert.New(t)
+
+		res, err := money.NewMoney(int8(100), 1).TrySplit(300)
+		assert.Nil(err)
+		assert.Len(res, 300)
+		assert.Equal(int8(0), res[0])
+		assert.Equal(int8(100), res[299])
+
+		res, err = money.NewMoney(int8(100), 1).TrySplit(300, money.WithSpread(money.SpreadFirst))
+		assert.Nil(err)
+		assert.Equal(int8(1), res[99])
+		assert.Equal(int8(0), res[100])
+
+		sum, err := money.TrySum(res)
+		assert.Nil(err)
+		assert.Equal(int8(100), sum)
+	})
+
+	t.Run("ratios sum up beyond T", func(t *testing.T) {
+		assert := assert.New(t)
+
+		res, err := money.NewMoney(int32(100), 1).TryAllocate([]int32{2_000_000_000, 2_000_000_000})
+		assert.Nil(err)
+		assert.Equal([]int32{50, 50}, res)
+
+		res8, err := money.NewMoney(uint8(100), 1).TryAllocate([]uint8{200, 200, 100})
+		assert.Nil(err)
+		assert.Equal([]uint8{40, 40, 20}, res8)
+
+		res8, err = money.NewMoney(uint8(100), 1).TryAllocate([]uint8{200, 200, 100}, money.WithStrategy(money.LargestRemainder))
+		assert.Nil(err)
+		assert.Equal([]uint8{40, 40, 20}, res8)
+	})
+
+	t.Run("promotes to BigMoney", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoneyWithCurrency(int8(100), money.USD)
+		_, err := m.Add(m)
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		b, err := m.Big().Add(m.Big())
+		assert.Nil(err)
+		assert.Equal(big.NewInt(200), b.Amount())
+		assert.Equal(money.USD, b.Currency())
+
+		_, err = money.MoneyFromBig[int8](b)
+		assert.True(errors.Is(err, money.ErrOverflow))
+
+		m16, err := money.MoneyFromBig[int16](b)
+		assert.Nil(err)
+		assert.Equal(int16(200), m16.Amount())
+		assert.Equal(money.USD, m16.Currency())
+	})
+}
@@ -0,0 +1,215 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var ErrSyntax = errors.New("money: invalid syntax")
+
+// ParseHint tells ParseMoney how to read the input. Both Currency and Locale
+// are hints.
+type ParseHint interface {
+	parseHint(*parseConfig)
+}
+
+type parseConfig struct {
+	currency Currency
+	locale   *Locale
+}
+
+func (c Currency) parseHint(p *parseConfig) {
+	p.currency = c
+}
+
+func (l Locale) parseHint(p *parseConfig) {
+	p.locale = &l
+}
+
+// ParseMoney parses human-entered amounts, e.g. $50.30, 1,234.56 or
+// Rp534.000, into Money in the smallest unit.
+//
+// Without a Currency hint, the currency is detected from the symbol or code
+// in the input. Without a Locale hint, the separators of the currency's
+// default locale are used, see LocaleOf.
+//
+// Amounts with more digits than the currency's exponent, or that are not a
+// multiple of the unit, fail with ErrFractionalAmount.
+func ParseMoney(s string, hints ...ParseHint) (*Money[int64], error) {
+	amount, currency, err := parse(s, hints)
+	if err != nil {
+		return nil, err
+	}
+
+	if !amount.IsInt64() {
+		return nil, fmt.Errorf("%w: %q", ErrOverflow, s)
+	}
+
+	m := NewMoneyWithCurrency(amount.Int64(), currency)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ParseBigMoney is like ParseMoney, but for BigMoney.
+func ParseBigMoney(s string, hints ...ParseHint) (*BigMoney, error) {
+	amount, currency, err := parse(s, hints)
+	if err != nil {
+		return nil, err
+	}
+
+	m := NewBigMoneyWithCurrency(amount, currency)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func parse(s string, hints []ParseHint) (*big.Int, Currency, error) {
+	var cfg parseConfig
+	for _, h := range hints {
+		h.parseHint(&cfg)
+	}
+
+	num := strings.TrimSpace(s)
+
+	var neg bool
+	if strings.HasPrefix(num, "(") && strings.HasSuffix(num, ")") {
+		num = strings.TrimSpace(num[1 : len(num)-1])
+		neg = true
+	}
+
+	if strings.HasPrefix(num, "-") {
+		num = strings.TrimSpace(num[1:])
+		neg = true
+	}
+
+	num, currency, err := cutCurrency(num, cfg.currency)
+	if err != nil {
+		return nil, Currency{}, fmt.Errorf("%w: %q", err, s)
+	}
+
+	if strings.HasPrefix(num, "-") && !neg {
+		num = strings.TrimSpace(num[1:])
+		neg = true
+	}
+
+	l := LocaleOf(currency)
+	if cfg.locale != nil {
+		l = *cfg.locale
+	}
+
+	amount, err := parseNumber(num, l, currency.Exponent)
+	if err != nil {
+		return nil, Currency{}, fmt.Errorf("%w: %q", err, s)
+	}
+
+	if neg {
+		amount.Neg(amount)
+	}
+
+	return amount, currency, nil
+}
+
+// cutCurrency removes the longest leading or trailing symbol or code. Without
+// a hint, all registered currencies are considered.
+func cutCurrency(s string, hint Currency) (string, Currency, error) {
+	candidates := currencies
+	if !hint.IsZero() {
+		candidates = []Currency{hint}
+	}
+
+	var (
+		token   string
+		matches []Currency
+		rest    string
+	)
+	for _, c := range candidates {
+		for _, t := range []string{c.Code, c.Symbol} {
+			if t == "" || len(t) < len(token) {
+				continue
+			}
+
+			var r string
+			switch {
+			case strings.HasPrefix(s, t):
+				r = s[len(t):]
+			case strings.HasSuffix(s, t):
+				r = s[:len(s)-len(t)]
+			default:
+				continue
+			}
+
+			if len(t) > len(token) {
+				token, matches = t, nil
+			}
+
+			if len(matches) == 0 || !matches[len(matches)-1].Equal(c) {
+				matches = append(matches, c)
+			}
+			rest = r
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return strings.TrimSpace(rest), matches[0], nil
+	case len(matches) > 1:
+		return "", Currency{}, fmt.Errorf("%w: ambiguous symbol %q", ErrUnknownCurrency, token)
+	case !hint.IsZero():
+		return s, hint, nil
+	default:
+		return "", Currency{}, ErrUnknownCurrency
+	}
+}
+
+// parseNumber parses the digits into the smallest unit, e.g. 1,234.56 with
+// exponent 2 into 123456.
+func parseNumber(s string, l Locale, exp int) (*big.Int, error) {
+	if l.Group != "" {
+		s = strings.ReplaceAll(s, l.Group, "")
+	}
+
+	// Spaces used for grouping are often typed as regular spaces, or copied
+	// as non-breaking ones.
+	if strings.TrimSpace(l.Group) == "" {
+		s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "").Replace(s)
+	}
+
+	major, minor := s, ""
+	if l.Decimal != "" {
+		major, minor, _ = strings.Cut(s, l.Decimal)
+	}
+
+	if !isDigits(major) || !isDigits(minor) || (major == "" && minor == "") {
+		return nil, ErrSyntax
+	}
+
+	minor = strings.TrimRight(minor, "0")
+	if len(minor) > exp {
+		return nil, ErrFractionalAmount
+	}
+
+	digits := major + minor + strings.Repeat("0", exp-len(minor))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, ErrSyntax
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,90 @@
+package money_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		hints    []money.ParseHint
+		amount   int64
+		currency money.Currency
+		scenario string
+	}{
+		{input: "1,234.56", hints: []money.ParseHint{money.USD}, amount: 123456, currency: money.USD, scenario: "currency hint"},
+		{input: "$50.30", amount: 5030, currency: money.USD, scenario: "symbol"},
+		{input: "S$50.30", amount: 5030, currency: money.SGD, scenario: "longer symbol"},
+		{input: "USD 50.3", amount: 5030, currency: money.USD, scenario: "code"},
+		{input: "50.30 USD", amount: 5030, currency: money.USD, scenario: "trailing code"},
+		{input: "Rp534.000", hints: []money.ParseHint{money.IdID}, amount: 534000, currency: money.IDR, scenario: "locale hint"},
+		{input: "Rp 534.000", amount: 534000, currency: money.IDR, scenario: "default locale"},
+		{input: "1 234,56 €", amount: 123456, currency: money.EUR, scenario: "fr-FR"},
+		{input: "1\u202f234,56 €", amount: 123456, currency: money.EUR, scenario: "narrow no-break space"},
+		{input: "€1,234.56", hints: []money.ParseHint{money.EnUS}, amount: 123456, currency: money.EUR, scenario: "locale overrides currency locale"},
+		{input: "50.300", hints: []money.ParseHint{money.USD}, amount: 5030, currency: money.USD, scenario: "trailing zeros"},
+		{input: "¥500", hints: []money.ParseHint{money.JPY}, amount: 500, currency: money.JPY, scenario: "ambiguous symbol with hint"},
+		{input: "  $0.05 ", amount: 5, currency: money.USD, scenario: "whitespace"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := money.ParseMoney(test.input, test.hints...)
+			assert.Nil(err)
+			assert.Equal(test.amount, m.Amount())
+			assert.Equal(test.currency, m.Currency())
+
+			b, err := money.ParseBigMoney(test.input, test.hints...)
+			assert.Nil(err)
+			assert.Equal(test.amount, b.Amount().Int64())
+			assert.Equal(test.currency, b.Currency())
+		})
+	}
+}
+
+func TestParseMoneyErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		hints    []money.ParseHint
+		err      error
+		scenario string
+	}{
+		{input: "50.305", hints: []money.ParseHint{money.USD}, err: money.ErrFractionalAmount, scenario: "more precision than the currency"},
+		{input: "¥500.5", hints: []money.ParseHint{money.JPY}, err: money.ErrFractionalAmount, scenario: "fraction without exponent"},
+		{input: "50.32", hints: []money.ParseHint{money.SGD.WithUnit(5)}, err: money.ErrFractionalAmount, scenario: "not a multiple of unit"},
+		{input: "50.30", err: money.ErrUnknownCurrency, scenario: "no currency"},
+		{input: "¥500", err: money.ErrUnknownCurrency, scenario: "ambiguous symbol"},
+		{input: "$5o.30", err: money.ErrSyntax, scenario: "not a number"},
+		{input: "$", err: money.ErrSyntax, scenario: "empty"},
+		{input: "-$50.30", err: money.ErrNegativeAmount, scenario: "negative"},
+		{input: "($50.30)", err: money.ErrNegativeAmount, scenario: "negative parentheses"},
+		{input: "$99,999,999,999,999,999.99", err: money.ErrOverflow, scenario: "overflow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, err := money.ParseMoney(test.input, test.hints...)
+			assert.True(errors.Is(err, test.err), err)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, c := range []money.Currency{money.USD, money.SGD, money.IDR, money.EUR, money.KWD, money.JPY, money.CHF} {
+		m := money.NewMoneyWithCurrency(int64(123456789), c)
+
+		p, err := money.ParseMoney(m.String(), c)
+		assert.Nil(err)
+		assert.True(m.Equal(p), "%s: %s", c, m)
+	}
+}
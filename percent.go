@@ -3,15 +3,22 @@ package money
 import (
 	"errors"
 	"fmt"
+	"math/big"
 )
 
 var (
 	ErrPercentOutOfRange = errors.New("money: percent must be between 0 and 100")
 )
 
-// Percent is a value between 0 and 100.
+// Percent is a whole number between 0 and 100, see Percentage for fractions,
+// e.g. 12.5%.
 type Percent uint
 
+// Percentage implements Percenter.
+func (p Percent) Percentage() Percentage {
+	return Percentage{r: new(big.Rat).SetFrac64(int64(p), 100)}
+}
+
 // Valid returns true if the range is valid.
 func (p Percent) Valid() bool {
 	return p.Validate() == nil
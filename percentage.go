@@ -0,0 +1,220 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var hundred = big.NewRat(100, 1)
+
+// percentUnits are the suffixes read by ParsePercentage.
+var percentUnits = []struct {
+	suffix string
+	scale  *big.Rat
+}{
+	{"%", hundred},
+	{"‰", big.NewRat(1_000, 1)},
+	{"bps", big.NewRat(10_000, 1)},
+	{"bp", big.NewRat(10_000, 1)},
+}
+
+// Percenter is a percentage accepted by DiscountPercentage, either a
+// Percent or a Percentage.
+type Percenter interface {
+	Percentage() Percentage
+}
+
+// Percentage is a fractional percent, e.g. 8.875%, unlike Percent which only
+// holds whole numbers.
+type Percentage struct {
+	// r is the fraction of one, e.g. 0.08875 for 8.875%.
+	r *big.Rat
+}
+
+// NewPercentage returns the percentage num/den, e.g. NewPercentage(8875, 1000)
+// for 8.875%.
+func NewPercentage(num, den int64) Percentage {
+	r := big.NewRat(num, den)
+	return Percentage{r: r.Quo(r, hundred)}
+}
+
+// BasisPoints returns the percentage in hundredths of a percent, e.g.
+// BasisPoints(35) for 0.35%.
+func BasisPoints(n int64) Percentage {
+	return Percentage{r: big.NewRat(n, 10_000)}
+}
+
+// Permille returns the percentage in tenths of a percent, e.g. Permille(29)
+// for 2.9%.
+func Permille(n int64) Percentage {
+	return Percentage{r: big.NewRat(n, 1_000)}
+}
+
+// ParsePercentage parses decimal percentages, with or without the percent
+// sign, e.g. 12.5%. Permille and basis points are read with their suffix,
+// e.g. 29‰ or 35bp.
+func ParsePercentage(s string) (Percentage, error) {
+	num := strings.TrimSpace(s)
+
+	scale := hundred
+	for _, u := range percentUnits {
+		if strings.HasSuffix(num, u.suffix) {
+			num, scale = strings.TrimSpace(strings.TrimSuffix(num, u.suffix)), u.scale
+			break
+		}
+	}
+
+	if num == "" || strings.ContainsAny(num, "/eE") {
+		return Percentage{}, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return Percentage{}, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	return Percentage{r: r.Quo(r, scale)}, nil
+}
+
+// MustParsePercentage is like ParsePercentage, but panics if the string
+// cannot be parsed.
+func MustParsePercentage(s string) Percentage {
+	p, err := ParsePercentage(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// Percentage implements Percenter.
+func (p Percentage) Percentage() Percentage {
+	return p
+}
+
+// Rat returns the percentage as a fraction of one, e.g. 0.08875 for 8.875%.
+func (p Percentage) Rat() *big.Rat {
+	if p.r == nil {
+		return new(big.Rat)
+	}
+
+	return new(big.Rat).Set(p.r)
+}
+
+// Cmp compares both percentages, and returns -1, 0 or +1.
+func (p Percentage) Cmp(o Percentage) int {
+	return p.Rat().Cmp(o.Rat())
+}
+
+// Validate checks if the percentage is between 0 and 100.
+func (p Percentage) Validate() error {
+	return p.validate(false)
+}
+
+// validate checks if the percentage is between 0 and 100, or only that it is
+// not negative when uncapped, see WithUncappedPercent.
+func (p Percentage) validate(uncapped bool) error {
+	r := p.Rat()
+	if r.Sign() < 0 || (!uncapped && r.Cmp(big.NewRat(1, 1)) > 0) {
+		return fmt.Errorf("%w: %s", ErrPercentOutOfRange, p)
+	}
+
+	return nil
+}
+
+// String returns the percentage with up to 4 decimals for display, e.g.
+// 8.875%. See MarshalText for the exact value.
+func (p Percentage) String() string {
+	s := new(big.Rat).Mul(p.Rat(), hundred).FloatString(4)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+
+	return s + "%"
+}
+
+// MarshalText implements encoding.TextMarshaler. Unlike String, it keeps
+// every decimal, e.g. 8.87512%, and writes percentages that have no exact
+// decimal as a fraction, e.g. 100/3%, so that UnmarshalText reads back the
+// same value.
+func (p Percentage) MarshalText() ([]byte, error) {
+	x := new(big.Rat).Mul(p.Rat(), hundred)
+
+	n, ok := decimals(x.Denom())
+	if !ok {
+		return []byte(x.RatString() + "%"), nil
+	}
+
+	s := x.FloatString(n)
+	if n > 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return []byte(s + "%"), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParsePercentage. It
+// also reads the fractions written by MarshalText, e.g. 100/3%.
+func (p *Percentage) UnmarshalText(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if num := strings.TrimSuffix(s, "%"); num != s && strings.Contains(num, "/") {
+		r, ok := new(big.Rat).SetString(num)
+		if !ok || strings.ContainsAny(num, ".eE") {
+			return fmt.Errorf("%w: %q", ErrSyntax, b)
+		}
+
+		*p = Percentage{r: r.Quo(r, hundred)}
+
+		return nil
+	}
+
+	res, err := ParsePercentage(s)
+	if err != nil {
+		return err
+	}
+
+	*p = res
+
+	return nil
+}
+
+// WithUncappedPercent allows percentages above 100% in Discount, Markup and
+// Surcharge.
+func WithUncappedPercent() Option {
+	return func(o *options) {
+		o.uncapped = true
+		o.set |= optUncapped
+	}
+}
+
+// decimals returns the number of decimals of a fraction with the
+// denominator, and false if the decimals do not terminate.
+func decimals(den *big.Int) (int, bool) {
+	d := new(big.Int).Set(den)
+
+	var twos, fives int
+	for d.Bit(0) == 0 {
+		d.Rsh(d, 1)
+		twos++
+	}
+
+	five, mod := big.NewInt(5), new(big.Int)
+	for {
+		q, m := new(big.Int).QuoRem(d, five, mod)
+		if m.Sign() != 0 {
+			break
+		}
+
+		d = q
+		fives++
+	}
+
+	if d.Cmp(one) != 0 {
+		return 0, false
+	}
+
+	if twos > fives {
+		return twos, true
+	}
+
+	return fives, true
+}
@@ -0,0 +1,169 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePercentage(t *testing.T) {
+	tests := []struct {
+		s        string
+		rat      *big.Rat
+		str      string
+		scenario string
+	}{
+		{s: "8.875%", rat: big.NewRat(8875, 100000), str: "8.875%", scenario: "fractional"},
+		{s: " 12.5 ", rat: big.NewRat(1, 8), str: "12.5%", scenario: "without sign"},
+		{s: "0.35 %", rat: big.NewRat(35, 10000), str: "0.35%", scenario: "space before sign"},
+		{s: "29‰", rat: big.NewRat(29, 1000), str: "2.9%", scenario: "permille"},
+		{s: "35bp", rat: big.NewRat(35, 10000), str: "0.35%", scenario: "basis points"},
+		{s: "1 bps", rat: big.NewRat(1, 10000), str: "0.01%", scenario: "basis point"},
+		{s: "150%", rat: big.NewRat(3, 2), str: "150%", scenario: "above 100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			p, err := money.ParsePercentage(test.s)
+			assert.Nil(err)
+			assert.Equal(test.rat, p.Rat())
+			assert.Equal(test.str, p.String())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"", "%", "bp", "abc", "1/2", "1e2", "1%%"} {
+			_, err := money.ParsePercentage(s)
+			assert.True(t, errors.Is(err, money.ErrSyntax), s)
+		}
+
+		assert.Panics(t, func() {
+			money.MustParsePercentage("abc")
+		})
+	})
+}
+
+func TestPercentage(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, money.BasisPoints(35).Cmp(money.NewPercentage(35, 100)))
+	assert.Equal(0, money.Permille(29).Cmp(money.MustParsePercentage("2.9%")))
+	assert.Equal(0, money.Percent(5).Percentage().Cmp(money.NewPercentage(5, 1)))
+	assert.Equal(-1, money.BasisPoints(1).Cmp(money.Permille(1)))
+
+	assert.Equal("0%", money.Percentage{}.String())
+	assert.Nil(money.NewPercentage(100, 1).Validate())
+	assert.True(errors.Is(money.NewPercentage(-1, 1).Validate(), money.ErrPercentOutOfRange))
+	assert.True(errors.Is(money.BasisPoints(10_001).Validate(), money.ErrPercentOutOfRange))
+}
+
+func TestPercentageJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	type fee struct {
+		Rate money.Percentage `json:"rate"`
+	}
+
+	b, err := json.Marshal(fee{Rate: money.BasisPoints(290)})
+	assert.Nil(err)
+	assert.Equal(`{"rate":"2.9%"}`, string(b))
+
+	var f fee
+	assert.Nil(json.Unmarshal(b, &f))
+	assert.Equal(0, f.Rate.Cmp(money.Permille(29)))
+
+	assert.True(errors.Is(json.Unmarshal([]byte(`{"rate":"x"}`), &f), money.ErrSyntax))
+	assert.True(errors.Is(json.Unmarshal([]byte(`{"rate":"1.5/3%"}`), &f), money.ErrSyntax))
+}
+
+func TestPercentageTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		p        money.Percentage
+		text     string
+		scenario string
+	}{
+		{p: money.NewPercentage(1, 3), text: "1/3%", scenario: "repeating"},
+		{p: money.NewPercentage(200, 3), text: "200/3%", scenario: "repeating above 1"},
+		{p: money.NewPercentage(887512, 100000), text: "8.87512%", scenario: "more than 4 decimals"},
+		{p: money.NewPercentage(1, 1024), text: "0.0009765625%", scenario: "power of two"},
+		{p: money.NewPercentage(150, 1), text: "150%", scenario: "whole"},
+		{p: money.Percentage{}, text: "0%", scenario: "zero"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			b, err := test.p.MarshalText()
+			assert.Nil(err)
+			assert.Equal(test.text, string(b))
+
+			var got money.Percentage
+			assert.Nil(got.UnmarshalText(b))
+			assert.Equal(0, test.p.Cmp(got))
+		})
+	}
+
+	assert.Equal(t, "0.3333%", money.NewPercentage(1, 3).String())
+}
+
+func TestDiscountPercentage(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		unit     int64
+		percent  money.Percenter
+		opts     []money.Option
+		expected int64
+		scenario string
+	}{
+		{amount: 1000, unit: 1, percent: money.MustParsePercentage("12.5%"), expected: 125, scenario: "fractional"},
+		{amount: 1001, unit: 1, percent: money.MustParsePercentage("12.5%"), expected: 126, scenario: "rounds up"},
+		{amount: 10000, unit: 1, percent: money.BasisPoints(35), expected: 35, scenario: "card fee"},
+		{amount: 1000, unit: 5, percent: money.Permille(29), expected: 30, scenario: "unit 5"},
+		{amount: 1000, unit: 1, percent: money.Percent(5), expected: 50, scenario: "whole percent"},
+		{amount: 1000, unit: 1, percent: money.NewPercentage(150, 1), opts: []money.Option{money.WithUncappedPercent()}, expected: 1500, scenario: "uncapped"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, test.unit)
+			assert.Equal(test.expected, m.DiscountPercentage(test.percent, test.opts...))
+
+			b := money.NewBigMoney(big.NewInt(test.amount), big.NewInt(test.unit))
+			assert.Equal(test.expected, b.DiscountPercentage(test.percent, test.opts...).Int64())
+		})
+	}
+
+	t.Run("whole percent constant", func(t *testing.T) {
+		assert := assert.New(t)
+
+		assert.Equal(int64(252), money.USD.New(50, 30).Discount(5))
+		assert.Equal(int64(252), money.NewBigMoneyWithCurrency(big.NewInt(5030), money.USD).Discount(5).Int64())
+	})
+
+	t.Run("above 100", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewMoney(100, 1).TryDiscountPercentage(money.NewPercentage(150, 1))
+		assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+
+		_, err = money.NewBigMoney(big.NewInt(100), big.NewInt(1)).TryDiscountPercentage(money.NewPercentage(150, 1))
+		assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+
+		_, err = money.NewMoney(100, 1).TryDiscountPercentage(money.NewPercentage(-1, 1), money.WithUncappedPercent())
+		assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := money.NewMoney(int8(100), 1).TryDiscountPercentage(money.NewPercentage(200, 1), money.WithUncappedPercent())
+		assert.True(t, errors.Is(err, money.ErrOverflow))
+	})
+}
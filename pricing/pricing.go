@@ -0,0 +1,221 @@
+// Package pricing applies ordered discount rules, such as percentage off,
+// buy-X-get-Y and tiered thresholds, to a cart of line items, and explains
+// which rules applied.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alextanhongpin/money"
+)
+
+var (
+	ErrEmptyCart       = errors.New("pricing: cart has no lines")
+	ErrInvalidQuantity = errors.New("pricing: quantity must be positive")
+	ErrInvalidRule     = errors.New("pricing: invalid rule")
+)
+
+// Line is a line of a cart. All lines must share the same currency and unit.
+type Line struct {
+	SKU      string
+	Price    *money.Money[int64]
+	Quantity int64
+}
+
+// Amount returns the price times the quantity. It panics if the amount
+// overflows, which Apply reports as an error.
+func (l Line) Amount() *money.Money[int64] {
+	return l.Price.MulInt(l.Quantity)
+}
+
+// Promotion is a named rule. Promotions are applied in order, so earlier
+// promotions take precedence.
+type Promotion struct {
+	Name string
+	Rule Rule
+
+	// Exclusive promotions do not combine with any other. An exclusive
+	// promotion is skipped if an earlier promotion applied, and no
+	// promotion applies after it.
+	Exclusive bool
+}
+
+// Step explains a promotion, in the order they were considered.
+type Step struct {
+	Promotion string
+	Applied   bool
+
+	// Reason is why the promotion was skipped.
+	Reason string
+
+	// Discount is the total discount of the promotion, and the sum of
+	// Lines.
+	Discount *money.Money[int64]
+
+	// Lines is the discount of the promotion on each line.
+	Lines []*money.Money[int64]
+}
+
+// Result is the priced cart. For each line, Amount - Discount equals Total,
+// and the lines sum up to the totals exactly.
+type Result struct {
+	Lines []LineResult
+	Steps []Step
+
+	Subtotal *money.Money[int64]
+	Discount *money.Money[int64]
+	Total    *money.Money[int64]
+}
+
+// LineResult is a priced line.
+type LineResult struct {
+	Line     Line
+	Amount   *money.Money[int64]
+	Discount *money.Money[int64]
+	Total    *money.Money[int64]
+}
+
+// Applied returns the names of the promotions that applied, in order.
+func (r *Result) Applied() []string {
+	var res []string
+	for _, s := range r.Steps {
+		if s.Applied {
+			res = append(res, s.Promotion)
+		}
+	}
+
+	return res
+}
+
+// Apply applies the promotions in order to the lines. Each promotion sees
+// what is left of each line after the promotions before it, so that no line
+// goes below zero.
+func Apply(lines []Line, promotions []Promotion) (*Result, error) {
+	if len(lines) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	amounts := make([]*money.Money[int64], len(lines))
+	left := make([]*money.Money[int64], len(lines))
+	for i, l := range lines {
+		if l.Quantity < 1 {
+			return nil, fmt.Errorf("%w: line %d: %d", ErrInvalidQuantity, i, l.Quantity)
+		}
+
+		if err := l.Price.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+
+		if _, err := lines[0].Price.Add(l.Price.WithAmount(0)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+
+		amount, err := l.Price.TryMulInt(l.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+
+		amounts[i], left[i] = amount, amount
+	}
+
+	var (
+		steps     []Step
+		applied   bool
+		exclusive string
+	)
+	for _, p := range promotions {
+		step := Step{Promotion: p.Name}
+
+		switch {
+		case exclusive != "":
+			step.Reason = fmt.Sprintf("exclusive promotion %q applied", exclusive)
+		case p.Exclusive && applied:
+			step.Reason = "exclusive promotion does not combine with earlier promotions"
+		default:
+			discounts, err := p.Rule.Apply(lines, left)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Name, err)
+			}
+
+			if discounts != nil && len(discounts) != len(lines) {
+				return nil, fmt.Errorf("%w: %s returned %d discounts for %d lines", ErrInvalidRule, p.Name, len(discounts), len(lines))
+			}
+
+			total, err := sum(lines[0].Price, discounts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Name, err)
+			}
+
+			if discounts == nil || total.IsZero() {
+				step.Reason = "conditions not met"
+				break
+			}
+
+			for i, d := range discounts {
+				if d.Amount() < 0 || d.Amount() > left[i].Amount() {
+					return nil, fmt.Errorf("%w: %s discounts line %d by %d of %d", ErrInvalidRule, p.Name, i, d.Amount(), left[i].Amount())
+				}
+
+				left[i], _ = left[i].Sub(d)
+			}
+
+			step.Applied = true
+			step.Discount = total
+			step.Lines = discounts
+			applied = true
+			if p.Exclusive {
+				exclusive = p.Name
+			}
+		}
+
+		steps = append(steps, step)
+	}
+
+	res := &Result{
+		Lines: make([]LineResult, len(lines)),
+		Steps: steps,
+	}
+
+	for i, l := range lines {
+		discount, err := amounts[i].Sub(left[i])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+
+		res.Lines[i] = LineResult{
+			Line:     l,
+			Amount:   amounts[i],
+			Discount: discount,
+			Total:    left[i],
+		}
+	}
+
+	var err error
+	if res.Subtotal, err = sum(lines[0].Price, amounts); err != nil {
+		return nil, fmt.Errorf("subtotal: %w", err)
+	}
+
+	if res.Total, err = sum(lines[0].Price, left); err != nil {
+		return nil, fmt.Errorf("total: %w", err)
+	}
+
+	if res.Discount, err = res.Subtotal.Sub(res.Total); err != nil {
+		return nil, fmt.Errorf("discount: %w", err)
+	}
+
+	return res, nil
+}
+
+// sum returns the sum of the amounts, in the currency and unit of m.
+func sum(m *money.Money[int64], amounts []*money.Money[int64]) (*money.Money[int64], error) {
+	res := m.WithAmount(0)
+	for _, a := range amounts {
+		var err error
+		if res, err = res.Add(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
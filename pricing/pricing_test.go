@@ -0,0 +1,211 @@
+package pricing_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/alextanhongpin/money/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func usd(cents int64) *money.Money[int64] {
+	return money.NewMoneyWithCurrency(cents, money.USD)
+}
+
+func cart() []pricing.Line {
+	return []pricing.Line{
+		{SKU: "shirt", Price: usd(2000), Quantity: 3},
+		{SKU: "socks", Price: usd(500), Quantity: 4},
+		{SKU: "hat", Price: usd(1500), Quantity: 1},
+	}
+}
+
+func amounts(ms []*money.Money[int64]) []int64 {
+	res := make([]int64, len(ms))
+	for i, m := range ms {
+		res[i] = m.Amount()
+	}
+
+	return res
+}
+
+func TestApply(t *testing.T) {
+	assert := assert.New(t)
+
+	res, err := pricing.Apply(cart(), []pricing.Promotion{
+		{Name: "socks 3 for 2", Rule: pricing.BuyXGetY{SKU: "socks", Buy: 2, Get: 1}},
+		{Name: "10% off shirts", Rule: pricing.PercentOff{Percent: money.NewPercentage(10, 1), SKUs: []string{"shirt"}}},
+		{Name: "$5 coupon", Rule: pricing.AmountOff{Amount: usd(500)}},
+		{Name: "VIP 20%", Rule: pricing.PercentOff{Percent: money.NewPercentage(20, 1)}, Exclusive: true},
+	})
+	assert.Nil(err)
+
+	assert.Equal([]string{"socks 3 for 2", "10% off shirts", "$5 coupon"}, res.Applied())
+	assert.Len(res.Steps, 4)
+	assert.Equal([]int64{0, 500, 0}, amounts(res.Steps[0].Lines))
+	assert.Equal([]int64{600, 0, 0}, amounts(res.Steps[1].Lines))
+	assert.Equal([]int64{322, 89, 89}, amounts(res.Steps[2].Lines))
+	assert.Equal(int64(500), res.Steps[2].Discount.Amount())
+	assert.False(res.Steps[3].Applied)
+	assert.Equal("exclusive promotion does not combine with earlier promotions", res.Steps[3].Reason)
+
+	assert.Equal(int64(9500), res.Subtotal.Amount())
+	assert.Equal(int64(1600), res.Discount.Amount())
+	assert.Equal(int64(7900), res.Total.Amount())
+	assert.Equal(money.USD, res.Total.Currency())
+
+	var total int64
+	for _, l := range res.Lines {
+		sum, err := l.Total.Add(l.Discount)
+		assert.Nil(err)
+		assert.True(sum.Equal(l.Amount))
+		total += l.Total.Amount()
+	}
+	assert.Equal(res.Total.Amount(), total)
+}
+
+func TestApplyExclusive(t *testing.T) {
+	assert := assert.New(t)
+
+	res, err := pricing.Apply(cart(), []pricing.Promotion{
+		{Name: "VIP 20%", Rule: pricing.PercentOff{Percent: money.NewPercentage(20, 1)}, Exclusive: true},
+		{Name: "$5 coupon", Rule: pricing.AmountOff{Amount: usd(500)}},
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"VIP 20%"}, res.Applied())
+	assert.Equal(`exclusive promotion "VIP 20%" applied`, res.Steps[1].Reason)
+	assert.Equal(int64(1900), res.Discount.Amount())
+}
+
+func TestBuyXGetY(t *testing.T) {
+	tests := []struct {
+		lines    []pricing.Line
+		rule     pricing.BuyXGetY
+		expected []int64
+		scenario string
+	}{
+		{
+			lines:    []pricing.Line{{SKU: "socks", Price: usd(500), Quantity: 2}},
+			rule:     pricing.BuyXGetY{SKU: "socks", Buy: 2, Get: 1},
+			scenario: "not enough items",
+		},
+		{
+			lines:    []pricing.Line{{SKU: "socks", Price: usd(500), Quantity: 7}},
+			rule:     pricing.BuyXGetY{SKU: "socks", Buy: 1, Get: 1},
+			expected: []int64{1500},
+			scenario: "buy 1 get 1",
+		},
+		{
+			lines: []pricing.Line{
+				{SKU: "socks", Price: usd(800), Quantity: 2},
+				{SKU: "socks", Price: usd(500), Quantity: 1},
+				{SKU: "hat", Price: usd(100), Quantity: 1},
+			},
+			rule:     pricing.BuyXGetY{SKU: "socks", Buy: 2, Get: 1},
+			expected: []int64{0, 500, 0},
+			scenario: "cheapest is free",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			res, err := pricing.Apply(test.lines, []pricing.Promotion{{Name: "bxgy", Rule: test.rule}})
+			assert.Nil(err)
+
+			if test.expected == nil {
+				assert.Empty(res.Applied())
+				assert.Equal("conditions not met", res.Steps[0].Reason)
+				return
+			}
+
+			assert.Equal(test.expected, amounts(res.Steps[0].Lines))
+		})
+	}
+}
+
+func TestTiered(t *testing.T) {
+	rule := pricing.Tiered{Tiers: []pricing.Tier{
+		{Min: usd(20000), Percent: money.NewPercentage(10, 1)},
+		{Min: usd(10000), Percent: money.NewPercentage(5, 1)},
+	}}
+
+	tests := []struct {
+		amount   int64
+		discount int64
+		scenario string
+	}{
+		{amount: 9999, discount: 0, scenario: "below every tier"},
+		{amount: 15000, discount: 750, scenario: "first tier"},
+		{amount: 20000, discount: 2000, scenario: "highest tier"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			res, err := pricing.Apply([]pricing.Line{{SKU: "tv", Price: usd(test.amount), Quantity: 1}}, []pricing.Promotion{
+				{Name: "spend more", Rule: rule},
+			})
+			assert.Nil(err)
+			assert.Equal(test.discount, res.Discount.Amount())
+		})
+	}
+}
+
+func TestAmountOffLimited(t *testing.T) {
+	assert := assert.New(t)
+
+	res, err := pricing.Apply([]pricing.Line{
+		{SKU: "hat", Price: usd(300), Quantity: 1},
+		{SKU: "shirt", Price: usd(2000), Quantity: 1},
+	}, []pricing.Promotion{
+		{Name: "$5 off hats", Rule: pricing.AmountOff{Amount: usd(500), SKUs: []string{"hat"}}},
+	})
+	assert.Nil(err)
+	assert.Equal([]int64{300, 0}, amounts(res.Steps[0].Lines))
+	assert.Equal(int64(2000), res.Total.Amount())
+}
+
+type badRule struct{}
+
+func (badRule) Apply(lines []pricing.Line, left []*money.Money[int64]) ([]*money.Money[int64], error) {
+	res := make([]*money.Money[int64], len(lines))
+	for i, l := range left {
+		res[i] = l.MulInt(2)
+	}
+
+	return res, nil
+}
+
+func TestApplyErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := pricing.Apply(nil, nil)
+	assert.True(errors.Is(err, pricing.ErrEmptyCart))
+
+	_, err = pricing.Apply([]pricing.Line{{SKU: "hat", Price: usd(100)}}, nil)
+	assert.True(errors.Is(err, pricing.ErrInvalidQuantity))
+
+	_, err = pricing.Apply([]pricing.Line{
+		{SKU: "hat", Price: usd(100), Quantity: 1},
+		{SKU: "hat", Price: money.NewMoneyWithCurrency[int64](100, money.SGD), Quantity: 1},
+	}, nil)
+	assert.True(errors.Is(err, money.ErrCurrencyMismatch))
+
+	_, err = pricing.Apply(cart(), []pricing.Promotion{{Name: "bad", Rule: pricing.BuyXGetY{SKU: "hat"}}})
+	assert.True(errors.Is(err, pricing.ErrInvalidRule))
+
+	_, err = pricing.Apply(cart(), []pricing.Promotion{{Name: "bad", Rule: badRule{}}})
+	assert.True(errors.Is(err, pricing.ErrInvalidRule))
+
+	// Each line fits, but the subtotal overflows.
+	_, err = pricing.Apply([]pricing.Line{
+		{SKU: "hat", Price: money.NewMoneyWithCurrency[int64](math.MaxInt64/2+1, money.USD), Quantity: 1},
+		{SKU: "cap", Price: money.NewMoneyWithCurrency[int64](math.MaxInt64/2+1, money.USD), Quantity: 1},
+	}, nil)
+	assert.True(errors.Is(err, money.ErrOverflow))
+}
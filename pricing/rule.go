@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/alextanhongpin/money"
+)
+
+// Rule computes the discount of a promotion on each line.
+type Rule interface {
+	// Apply returns the discount of each line, given what is left of each
+	// line after the earlier promotions. A nil result means the rule does
+	// not apply.
+	Apply(lines []Line, left []*money.Money[int64]) ([]*money.Money[int64], error)
+}
+
+// PercentOff takes the percent off the lines of the SKUs, or off every line
+// without SKUs. The discount is rounded up to the unit, and distributed
+// across the lines pro rata, see money.DistributePercentDiscount.
+type PercentOff struct {
+	Percent money.Percentage
+	SKUs    []string
+}
+
+func (r PercentOff) Apply(lines []Line, left []*money.Money[int64]) ([]*money.Money[int64], error) {
+	return money.DistributePercentDiscount(eligible(lines, left, r.SKUs), r.Percent)
+}
+
+// AmountOff takes the amount off the lines of the SKUs, or off every line
+// without SKUs, e.g. a 10.00 coupon. The discount is limited to what is left
+// of the lines, and distributed across them pro rata, see
+// money.DistributeDiscount.
+type AmountOff struct {
+	Amount *money.Money[int64]
+	SKUs   []string
+}
+
+func (r AmountOff) Apply(lines []Line, left []*money.Money[int64]) ([]*money.Money[int64], error) {
+	items := eligible(lines, left, r.SKUs)
+
+	total, err := sum(r.Amount, amounts(items))
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := r.Amount.Min(total)
+	if err != nil {
+		return nil, err
+	}
+
+	return money.DistributeDiscount(items, d)
+}
+
+// BuyXGetY gives Get items of the SKU free for every Buy items, e.g. buy 2
+// get 1 free. When the SKU is on several lines at different prices, the
+// cheapest items are free.
+type BuyXGetY struct {
+	SKU string
+	Buy int64
+	Get int64
+}
+
+func (r BuyXGetY) Apply(lines []Line, left []*money.Money[int64]) ([]*money.Money[int64], error) {
+	if r.Buy < 1 || r.Get < 1 {
+		return nil, fmt.Errorf("%w: buy %d get %d", ErrInvalidRule, r.Buy, r.Get)
+	}
+
+	var (
+		idx []int
+		qty int64
+	)
+	for i, l := range lines {
+		if l.SKU == r.SKU {
+			idx = append(idx, i)
+			qty += l.Quantity
+		}
+	}
+
+	free := qty / (r.Buy + r.Get) * r.Get
+	if free == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return lines[idx[i]].Price.Amount() < lines[idx[j]].Price.Amount()
+	})
+
+	res := zero(left)
+	for _, i := range idx {
+		n := lines[i].Quantity
+		if n > free {
+			n = free
+		}
+		free -= n
+
+		d, err := lines[i].Price.MulInt(n).Min(left[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = d
+
+		if free == 0 {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// Tier is a threshold of Tiered.
+type Tier struct {
+	Min     *money.Money[int64]
+	Percent money.Percentage
+}
+
+// Tiered takes the percent of the highest tier whose minimum is met by what
+// is left of the lines of the SKUs, or of every line without SKUs, e.g. 5%
+// off above 100.00 and 10% off above 200.00.
+type Tiered struct {
+	Tiers []Tier
+	SKUs  []string
+}
+
+func (r Tiered) Apply(lines []Line, left []*money.Money[int64]) ([]*money.Money[int64], error) {
+	items := eligible(lines, left, r.SKUs)
+
+	total, err := sum(left[0], amounts(items))
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Tier
+	for i, t := range r.Tiers {
+		met, err := total.Cmp(t.Min)
+		if err != nil {
+			return nil, err
+		}
+
+		if met < 0 {
+			continue
+		}
+
+		if best == nil || t.Min.Amount() > best.Min.Amount() {
+			best = &r.Tiers[i]
+		}
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+
+	return money.DistributePercentDiscount(items, best.Percent)
+}
+
+// eligible returns what is left of the lines of the SKUs, or of every line
+// without SKUs, and zero for the other lines.
+func eligible(lines []Line, left []*money.Money[int64], skus []string) []money.LineItem[int64] {
+	res := make([]money.LineItem[int64], len(lines))
+	for i, l := range lines {
+		res[i].Amount = left[i]
+		if len(skus) > 0 && !contains(skus, l.SKU) {
+			res[i].Amount = left[i].WithAmount(0)
+		}
+	}
+
+	return res
+}
+
+func amounts(items []money.LineItem[int64]) []*money.Money[int64] {
+	res := make([]*money.Money[int64], len(items))
+	for i, item := range items {
+		res[i] = item.Amount
+	}
+
+	return res
+}
+
+func zero(left []*money.Money[int64]) []*money.Money[int64] {
+	res := make([]*money.Money[int64], len(left))
+	for i, l := range left {
+		res[i] = l.WithAmount(0)
+	}
+
+	return res
+}
+
+func contains(skus []string, sku string) bool {
+	for _, s := range skus {
+		if s == sku {
+			return true
+		}
+	}
+
+	return false
+}
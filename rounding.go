@@ -0,0 +1,155 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrRoundingModeInvalid = errors.New("money: invalid rounding mode")
+
+// RoundingMode decides how a fractional number of units is rounded to a
+// whole number of units. Negative amounts round like their positive mirror
+// everywhere, so that a refund matches its sale, e.g. RoundFloor rounds -52.5
+// units to -52. See Money.Signed.
+type RoundingMode int
+
+const (
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor RoundingMode = iota
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+	// RoundDown rounds towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest neighbour, and away from zero when
+	// both neighbours are equidistant.
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest neighbour, and towards zero when
+	// both neighbours are equidistant.
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest neighbour, and to the even
+	// neighbour when both neighbours are equidistant, also known as banker's
+	// rounding.
+	RoundHalfEven
+)
+
+var roundingModeNames = map[RoundingMode]string{
+	RoundFloor:    "floor",
+	RoundCeiling:  "ceiling",
+	RoundDown:     "down",
+	RoundUp:       "up",
+	RoundHalfUp:   "half-up",
+	RoundHalfDown: "half-down",
+	RoundHalfEven: "half-even",
+}
+
+func (r RoundingMode) String() string {
+	if s, ok := roundingModeNames[r]; ok {
+		return s
+	}
+
+	return fmt.Sprintf("RoundingMode(%d)", int(r))
+}
+
+// Validate checks if the rounding mode is known.
+func (r RoundingMode) Validate() error {
+	if _, ok := roundingModeNames[r]; !ok {
+		return fmt.Errorf("%w: %d", ErrRoundingModeInvalid, int(r))
+	}
+
+	return nil
+}
+
+// Returns a new big.Int set to x rounded with the given mode.
+func round(x *big.Rat, mode RoundingMode) *big.Int {
+	// big.Rat keeps the denominator positive, so DivMod gives the floor and a
+	// non-negative remainder.
+	q, r := new(big.Int).DivMod(x.Num(), x.Denom(), new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	if roundAway(x.Sign() < 0, q.Bit(0) == 0, r.Cmp(new(big.Int).Sub(x.Denom(), r)), mode) {
+		q.Add(q, one)
+	}
+
+	return q
+}
+
+// roundAbs rounds the absolute value of x with the given mode, and keeps the
+// sign, so that negative amounts round like their positive mirror.
+func roundAbs(x *big.Rat, mode RoundingMode) *big.Int {
+	if x.Sign() >= 0 {
+		return round(x, mode)
+	}
+
+	res := round(new(big.Rat).Neg(x), mode)
+
+	return res.Neg(res)
+}
+
+// roundQuo rounds the quotient of n divided by d with the given mode.
+func roundQuo[T constraints.Integer](n, d T, mode RoundingMode) T {
+	q, r := n/d, n%d
+	if r == 0 {
+		return q
+	}
+
+	neg := (r < 0) != (d < 0)
+
+	// Go truncates towards zero, so shift the quotient down to the floor for
+	// negative results.
+	if neg {
+		q--
+		r += d
+	}
+
+	// Compare the remainder with the distance to the next multiple, without
+	// doubling the remainder.
+	ar, ad := r, d
+	if ad < 0 {
+		ar, ad = -ar, -ad
+	}
+
+	var half int
+	switch rest := ad - ar; {
+	case ar < rest:
+		half = -1
+	case ar > rest:
+		half = 1
+	}
+
+	if roundAway(neg, q%2 == 0, half, mode) {
+		q++
+	}
+
+	return q
+}
+
+// roundAway decides whether the floor of a fractional number should be
+// incremented. neg is the sign of the number, even whether the floor is even,
+// and half compares the fractional part against one half.
+func roundAway(neg, even bool, half int, mode RoundingMode) bool {
+	switch mode {
+	case RoundFloor:
+		return false
+	case RoundCeiling:
+		return true
+	case RoundDown:
+		return neg
+	case RoundUp:
+		return !neg
+	case RoundHalfUp:
+		return half > 0 || (half == 0 && !neg)
+	case RoundHalfDown:
+		return half > 0 || (half == 0 && neg)
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && !even)
+	default:
+		panic(fmt.Sprintf("money: unknown rounding mode %d", int(mode)))
+	}
+}
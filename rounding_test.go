@@ -0,0 +1,93 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundingMode(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		mode     money.RoundingMode
+		expected int64
+		scenario string
+	}{
+		// 5% of 1050 is 52.5.
+		{amount: 1050, mode: money.RoundFloor, expected: 52, scenario: "floor 52.5"},
+		{amount: 1050, mode: money.RoundCeiling, expected: 53, scenario: "ceiling 52.5"},
+		{amount: 1050, mode: money.RoundDown, expected: 52, scenario: "down 52.5"},
+		{amount: 1050, mode: money.RoundUp, expected: 53, scenario: "up 52.5"},
+		{amount: 1050, mode: money.RoundHalfUp, expected: 53, scenario: "half-up 52.5"},
+		{amount: 1050, mode: money.RoundHalfDown, expected: 52, scenario: "half-down 52.5"},
+		{amount: 1050, mode: money.RoundHalfEven, expected: 52, scenario: "half-even 52.5"},
+		// 5% of 1150 is 57.5.
+		{amount: 1150, mode: money.RoundHalfEven, expected: 58, scenario: "half-even 57.5"},
+		// 5% of 1030 is 51.5.
+		{amount: 1030, mode: money.RoundHalfEven, expected: 52, scenario: "half-even 51.5"},
+		// 5% of 1010 is 50.5.
+		{amount: 1010, mode: money.RoundHalfDown, expected: 50, scenario: "half-down 50.5"},
+		// 5% of 1011 is 50.55.
+		{amount: 1011, mode: money.RoundHalfDown, expected: 51, scenario: "half-down 50.55"},
+		{amount: 1011, mode: money.RoundHalfUp, expected: 51, scenario: "half-up 50.55"},
+		// 5% of 1009 is 50.45.
+		{amount: 1009, mode: money.RoundHalfUp, expected: 50, scenario: "half-up 50.45"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, 1)
+			assert.Equal(test.expected, m.Discount(money.Percent(5), money.WithRounding(test.mode)))
+
+			b := money.NewBigMoney(big.NewInt(test.amount), big.NewInt(1))
+			assert.Equal(test.expected, b.Discount(money.Percent(5), money.WithRounding(test.mode)).Int64())
+		})
+	}
+}
+
+func TestRoundingModeSplitAllocate(t *testing.T) {
+	t.Run("split", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoney(200, 1)
+		assert.Equal([]int{66, 66, 68}, m.Split(3))
+		assert.Equal([]int{67, 67, 66}, m.Split(3, money.WithRounding(money.RoundHalfUp)))
+		assert.Equal([]int{67, 67, 66}, m.Split(3, money.WithRounding(money.RoundCeiling)))
+
+		b := money.NewBigMoney(big.NewInt(200), big.NewInt(1))
+		assert.Equal(money.SumBig(b.Split(3, money.WithRounding(money.RoundHalfUp))), big.NewInt(200))
+		assert.Equal(big.NewInt(66), b.Split(3, money.WithRounding(money.RoundHalfUp))[2])
+	})
+
+	t.Run("allocate", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoney(100, 1)
+		assert.Equal([]int{16, 33, 51}, m.Allocate([]int{1, 2, 3}))
+		assert.Equal([]int{17, 33, 50}, m.Allocate([]int{1, 2, 3}, money.WithRounding(money.RoundHalfEven)))
+
+		b := money.NewBigMoney(big.NewInt(100), big.NewInt(1))
+		res := b.Allocate([]uint64{1, 2, 3}, money.WithRounding(money.RoundHalfEven))
+		assert.Equal([]*big.Int{big.NewInt(17), big.NewInt(33), big.NewInt(50)}, res)
+	})
+
+	t.Run("shares never exceed the amount", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoney(1, 1)
+		assert.Equal([]int{1, 0, 0}, m.Split(3, money.WithRounding(money.RoundUp)))
+		assert.Equal([]int{1, 0, 0}, m.Allocate([]int{1, 1, 1}, money.WithRounding(money.RoundUp)))
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := money.NewMoney(100, 1).TryDiscount(money.Percent(5), money.WithRounding(money.RoundingMode(100)))
+		assert.True(errors.Is(err, money.ErrRoundingModeInvalid))
+	})
+}
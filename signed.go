@@ -0,0 +1,103 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Signed returns a copy of the Money in signed mode, where negative amounts
+// are valid, e.g. for refunds, credits and chargebacks. Every operation on a
+// negative amount mirrors the positive amount, e.g. Split, Allocate, Discount,
+// Convert and the taxes, so that a refund matches its sale.
+func (m *Money[T]) Signed() *Money[T] {
+	res := m.WithAmount(m.amount)
+	res.signed = true
+
+	return res
+}
+
+// IsSigned returns true if negative amounts are valid, see Signed.
+func (m *Money[T]) IsSigned() bool {
+	return m.signed
+}
+
+// Abs returns the absolute amount. It panics if the amount is the smallest
+// signed T, which has no positive counterpart, see TryNeg.
+func (m *Money[T]) Abs() *Money[T] {
+	if m.amount < 0 {
+		return m.Neg()
+	}
+
+	return m.WithAmount(m.amount)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (m *Money[T]) Sign() int {
+	switch {
+	case m.amount < 0:
+		return -1
+	case m.amount > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// abs returns the absolute amount, which Split and Allocate divide before
+// negating the results, or ErrOverflow if it does not fit in T.
+func (m *Money[T]) abs() (T, error) {
+	if m.amount >= 0 {
+		return m.amount, nil
+	}
+
+	if isMin(m.amount) {
+		return 0, fmt.Errorf("%w: -%d", ErrOverflow, m.amount)
+	}
+
+	return -m.amount, nil
+}
+
+func negate[T constraints.Integer](res []T) {
+	for i := range res {
+		res[i] = -res[i]
+	}
+}
+
+// Signed is like Money.Signed.
+func (m *BigMoney) Signed() *BigMoney {
+	res := m.WithAmount(m.Amount())
+	res.signed = true
+
+	return res
+}
+
+// IsSigned is like Money.IsSigned.
+func (m *BigMoney) IsSigned() bool {
+	return m.signed
+}
+
+// Abs returns the absolute amount.
+func (m *BigMoney) Abs() *BigMoney {
+	return m.WithAmount(new(big.Int).Abs(m.amount))
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (m *BigMoney) Sign() int {
+	return m.amount.Sign()
+}
+
+// mirror runs f on the absolute amount, and negates the results.
+func (m *BigMoney) mirror(f func(*BigMoney) ([]*big.Int, error)) ([]*big.Int, error) {
+	res, err := f(m.Abs())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range res {
+		r.Neg(r)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,220 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigned(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.NewMoneyWithCurrency[int64](-5030, money.USD)
+	assert.False(m.IsSigned())
+	assert.True(errors.Is(m.Validate(), money.ErrNegativeAmount))
+
+	s := m.Signed()
+	assert.True(s.IsSigned())
+	assert.Nil(s.Validate())
+	assert.True(s.WithAmount(-1).IsSigned())
+	assert.True(s.Equal(m))
+
+	assert.True(errors.Is(money.NewMoney[int64](-3, 2).Signed().Validate(), money.ErrFractionalAmount))
+
+	assert.Equal(int64(5030), s.Abs().Amount())
+	assert.Equal(int64(5030), s.Neg().Amount())
+	assert.Equal(-1, s.Sign())
+	assert.Equal(1, s.Abs().Sign())
+	assert.Equal(0, s.WithAmount(0).Sign())
+
+	b := money.NewBigMoney(big.NewInt(-5030), big.NewInt(1))
+	assert.True(errors.Is(b.Validate(), money.ErrNegativeAmount))
+	assert.Nil(b.Signed().Validate())
+	assert.True(b.Signed().IsSigned())
+	assert.Equal(int64(5030), b.Abs().Amount().Int64())
+	assert.Equal(-1, b.Sign())
+}
+
+func TestSignedSplit(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		unit     int64
+		n        uint
+		opts     []money.Option
+		expected []int64
+		scenario string
+	}{
+		{amount: -1000, unit: 1, n: 3, expected: []int64{-333, -333, -334}, scenario: "remainder last"},
+		{amount: -1000, unit: 1, n: 3, opts: []money.Option{money.WithSpread(money.SpreadFirst)}, expected: []int64{-334, -333, -333}, scenario: "spread first"},
+		{amount: -1000, unit: 1, n: 3, opts: []money.Option{money.WithRounding(money.RoundCeiling)}, expected: []int64{-334, -334, -332}, scenario: "rounds up the magnitude"},
+		{amount: -1000, unit: 5, n: 3, expected: []int64{-330, -330, -340}, scenario: "unit 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, test.unit).Signed()
+			res, err := m.TrySplit(test.n, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, res)
+			assert.Equal(test.amount, money.Sum(res))
+
+			// Mirrors the positive amount.
+			assert.Equal(test.expected, negate(m.Abs().Split(test.n, test.opts...)))
+
+			b := money.NewBigMoney(big.NewInt(test.amount), big.NewInt(test.unit)).Signed()
+			bres, err := b.TrySplit(test.n, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, int64s(bres))
+		})
+	}
+}
+
+func TestSignedAllocate(t *testing.T) {
+	tests := []struct {
+		opts     []money.Option
+		expected []int64
+		scenario string
+	}{
+		{expected: []int64{-628, -1257, -3145}, scenario: "remainder last"},
+		{opts: []money.Option{money.WithStrategy(money.LargestRemainder)}, expected: []int64{-629, -1257, -3144}, scenario: "largest remainder"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney[int64](-5030, 1).Signed()
+			res, err := m.TryAllocate([]int64{1, 2, 5}, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, res)
+			assert.Equal(negate(m.Abs().Allocate([]int64{1, 2, 5}, test.opts...)), res)
+
+			b := money.NewBigMoney(big.NewInt(-5030), big.NewInt(1)).Signed()
+			bres, err := b.TryAllocate([]uint64{1, 2, 5}, test.opts...)
+			assert.Nil(err)
+			assert.Equal(test.expected, int64s(bres))
+		})
+	}
+}
+
+func TestSignedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := money.NewMoney[int64](-100, 1).TrySplit(3)
+	assert.True(errors.Is(err, money.ErrNegativeAmount))
+
+	_, err = money.NewMoney[int64](-100, 1).TryAllocate([]int64{1, 1})
+	assert.True(errors.Is(err, money.ErrNegativeAmount))
+
+	_, err = money.NewMoney(int8(-128), 1).Signed().TrySplit(2)
+	assert.True(errors.Is(err, money.ErrOverflow))
+}
+
+func TestSignedMirrors(t *testing.T) {
+	assert := assert.New(t)
+
+	refund := money.NewMoneyWithCurrency[int64](-1001, money.USD).Signed()
+
+	// The refund mirrors the sale, where 12.5% of 1001 rounds up to 126.
+	assert.Equal(int64(-126), refund.DiscountPercentage(money.NewPercentage(125, 10)))
+
+	tax, err := money.TaxExclusive(refund.WithAmount(-1000), money.NewPercentage(8875, 1000))
+	assert.Nil(err)
+	assert.Equal(int64(-89), tax.Tax.Amount())
+	assert.Equal(int64(-1089), tax.Gross.Amount())
+
+	rates := money.NewMemoryRates()
+	assert.Nil(rates.Set(money.USD, money.SGD, big.NewRat(135, 100)))
+	sgd, err := money.Convert(refund, money.SGD, rates)
+	assert.Nil(err)
+	assert.Equal(int64(-1351), sgd.Amount())
+
+	_, err = money.ApplyTaxes([]*money.Money[int64]{refund}, money.TaxStack{{Name: "GST", Rate: money.NewPercentage(9, 1)}})
+	assert.True(errors.Is(err, money.ErrNegativeAmount))
+}
+
+func TestSignedJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	b := []byte(`{"amount":-500,"unit":1,"currency":"USD"}`)
+
+	var m money.Money[int64]
+	assert.True(errors.Is(json.Unmarshal(b, &m), money.ErrNegativeAmount))
+
+	s := money.NewMoney[int64](0, 1).Signed()
+	assert.Nil(json.Unmarshal(b, s))
+	assert.Equal(int64(-500), s.Amount())
+	assert.True(s.IsSigned())
+
+	// The signed mode round-trips.
+	b, err := json.Marshal(money.USD.New(-5, 0).Signed())
+	assert.Nil(err)
+	assert.JSONEq(`{"amount":-500,"unit":1,"currency":"USD","signed":true}`, string(b))
+
+	var got money.Money[int64]
+	assert.Nil(json.Unmarshal(b, &got))
+	assert.True(got.IsSigned())
+	assert.Equal(int64(-500), got.Amount())
+
+	b, err = json.Marshal(money.NewBigMoney(big.NewInt(-500), big.NewInt(1)).Signed())
+	assert.Nil(err)
+
+	var bm money.BigMoney
+	assert.Nil(json.Unmarshal(b, &bm))
+	assert.True(bm.IsSigned())
+	assert.Equal(int64(-500), bm.Amount().Int64())
+}
+
+func negate(ns []int64) []int64 {
+	res := make([]int64, len(ns))
+	for i, n := range ns {
+		res[i] = -n
+	}
+
+	return res
+}
+
+func int64s(ns []*big.Int) []int64 {
+	res := make([]int64, len(ns))
+	for i, n := range ns {
+		res[i] = n.Int64()
+	}
+
+	return res
+}
+
+func TestSignedRounding(t *testing.T) {
+	// 5% of -1050 is -52.5, which rounds like 52.5.
+	tests := []struct {
+		mode     money.RoundingMode
+		expected int64
+	}{
+		{money.RoundFloor, -52},
+		{money.RoundCeiling, -53},
+		{money.RoundDown, -52},
+		{money.RoundUp, -53},
+		{money.RoundHalfUp, -53},
+		{money.RoundHalfDown, -52},
+		{money.RoundHalfEven, -52},
+	}
+
+	for _, test := range tests {
+		t.Run(test.mode.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney[int64](-1050, 1).Signed()
+			assert.Equal(test.expected, m.Discount(5, money.WithRounding(test.mode)))
+			assert.Equal(-m.Neg().Discount(5, money.WithRounding(test.mode)), m.Discount(5, money.WithRounding(test.mode)))
+			assert.Equal(test.expected, m.Big().Discount(5, money.WithRounding(test.mode)).Int64())
+
+			// Split rounds the same way.
+			assert.Equal(negate(m.Neg().Split(3, money.WithRounding(test.mode))), m.Split(3, money.WithRounding(test.mode)))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package money
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Spread decides which shares of Split receive the leftover units.
+type Spread int
+
+const (
+	// SpreadNone gives all leftover units to the last share.
+	SpreadNone Spread = iota
+
+	// SpreadFirst gives one leftover unit each to the first shares.
+	SpreadFirst
+
+	// SpreadLast gives one leftover unit each to the last shares.
+	SpreadLast
+
+	// SpreadShuffle gives one leftover unit each to randomly picked shares.
+	// The shares are picked deterministically from the seed, see WithShuffle.
+	SpreadShuffle
+)
+
+var spreadNames = map[Spread]string{
+	SpreadNone:    "none",
+	SpreadFirst:   "first",
+	SpreadLast:    "last",
+	SpreadShuffle: "shuffle",
+}
+
+func (s Spread) String() string {
+	if name, ok := spreadNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Spread(%d)", int(s))
+}
+
+// Validate checks if the spread is known.
+func (s Spread) Validate() error {
+	if _, ok := spreadNames[s]; !ok {
+		return fmt.Errorf("%w: %d", ErrSpreadInvalid, int(s))
+	}
+
+	return nil
+}
+
+// WithSpread sets how Split distributes the leftover units. The rounding mode
+// is ignored unless the spread is SpreadNone.
+func WithSpread(s Spread) Option {
+	return func(o *options) {
+		o.spread = s
+		o.set |= optSpread
+	}
+}
+
+// WithShuffle is like WithSpread with SpreadShuffle, picking the shares from
+// the seed.
+func WithShuffle(seed int64) Option {
+	return func(o *options) {
+		o.spread = SpreadShuffle
+		o.seed = seed
+		o.set |= optSpread
+	}
+}
+
+// spreadExtra returns a function that reports whether the i-th of n shares
+// receives one of the left units.
+func spreadExtra(n, left int, o options) func(i int) bool {
+	switch o.spread {
+	case SpreadFirst:
+		return func(i int) bool {
+			return i < left
+		}
+	case SpreadLast:
+		return func(i int) bool {
+			return i >= n-left
+		}
+	case SpreadShuffle:
+		picked := make(map[int]bool, left)
+		for _, i := range rand.New(rand.NewSource(o.seed)).Perm(n)[:left] {
+			picked[i] = true
+		}
+
+		return func(i int) bool {
+			return picked[i]
+		}
+	default:
+		panic(fmt.Sprintf("money: unknown spread %d", int(o.spread)))
+	}
+}
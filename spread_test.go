@@ -0,0 +1,82 @@
+package money_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleWithSpread() {
+	m := money.NewMoney(5030, 1)
+	s := m.Split(3, money.WithSpread(money.SpreadFirst))
+
+	fmt.Println(s, money.Sum(s))
+	// Output: [1677 1677 1676] 5030
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		unit     int64
+		split    uint
+		opt      money.Option
+		expected []int64
+		scenario string
+	}{
+		{amount: 5030, unit: 1, split: 3, opt: money.WithSpread(money.SpreadFirst), expected: []int64{1677, 1677, 1676}, scenario: "first"},
+		{amount: 5030, unit: 1, split: 3, opt: money.WithSpread(money.SpreadLast), expected: []int64{1676, 1677, 1677}, scenario: "last"},
+		{amount: 5030, unit: 5, split: 3, opt: money.WithSpread(money.SpreadFirst), expected: []int64{1680, 1675, 1675}, scenario: "first, unit 5"},
+		{amount: 100, unit: 1, split: 4, opt: money.WithSpread(money.SpreadFirst), expected: []int64{25, 25, 25, 25}, scenario: "no leftover"},
+		{amount: 2, unit: 1, split: 4, opt: money.WithSpread(money.SpreadLast), expected: []int64{0, 0, 1, 1}, scenario: "fewer units than shares"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.amount, test.unit)
+			assert.Equal(test.expected, m.Split(test.split, test.opt))
+
+			b := money.NewBigMoney(big.NewInt(test.amount), big.NewInt(test.unit))
+			res := b.Split(test.split, test.opt)
+			for i, exp := range test.expected {
+				assert.Equal(exp, res[i].Int64())
+			}
+		})
+	}
+}
+
+func TestSpreadShuffle(t *testing.T) {
+	assert := assert.New(t)
+
+	m := money.NewMoney(int64(10_003), 1)
+	res := m.Split(10, money.WithShuffle(42))
+	assert.Equal(m.Amount(), money.Sum(res))
+	assert.Equal(res, m.Split(10, money.WithShuffle(42)), "same seed gives the same split")
+
+	var extra int
+	for _, r := range res {
+		assert.True(r == 1000 || r == 1001)
+		if r == 1001 {
+			extra++
+		}
+	}
+	assert.Equal(3, extra)
+
+	b := money.NewBigMoney(big.NewInt(10_003), big.NewInt(1))
+	bres := b.Split(10, money.WithShuffle(42))
+	for i, r := range res {
+		assert.Equal(r, bres[i].Int64())
+	}
+}
+
+func TestSpreadInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := money.NewMoney(100, 1).TrySplit(3, money.WithSpread(money.Spread(100)))
+	assert.True(errors.Is(err, money.ErrSpreadInvalid))
+}
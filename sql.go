@@ -0,0 +1,283 @@
+package money
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Value implements driver.Valuer, storing the amount in the smallest unit in
+// an integer column. Amounts that do not fit in an int64 are stored as text
+// for NUMERIC columns.
+func (m *Money[T]) Value() (driver.Value, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return sqlValue(bigIntFromInteger(m.amount)), nil
+}
+
+// Scan implements sql.Scanner, reading the amount from an integer or NUMERIC
+// column. The unit and currency of the receiver are kept, and the amount is
+// validated against the unit.
+func (m *Money[T]) Scan(src any) error {
+	n, err := sqlScan(src)
+	if err != nil {
+		return err
+	}
+
+	amount, ok := integerFromBigInt[T](n)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOverflow, n)
+	}
+
+	unit, _ := integerFromBigInt[T](defaultUnit(bigIntFromInteger(m.unit), m.currency))
+	res := Money[T]{
+		amount:   amount,
+		unit:     unit,
+		currency: m.currency,
+		signed:   m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// ScanColumns returns the destinations for reading the amount and the
+// currency from two columns, e.g.
+//
+//	amount, currency := m.ScanColumns()
+//	err := row.Scan(&id, amount, currency)
+//
+// The amount is validated once both columns are scanned, against the unit of
+// the receiver if it has the same currency, or else the currency's unit.
+func (m *Money[T]) ScanColumns() (amount, currency sql.Scanner) {
+	c := &columns{m: m}
+	return &amountColumn{c}, &currencyColumn{c}
+}
+
+// ValueColumns returns the values for writing the amount and the currency to
+// two columns.
+func (m *Money[T]) ValueColumns() (amount, currency driver.Valuer) {
+	return m, m.currency
+}
+
+// Value implements driver.Valuer, storing the amount as text for NUMERIC
+// columns.
+func (m *BigMoney) Value() (driver.Value, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m.amount.String(), nil
+}
+
+// Scan implements sql.Scanner, see Money.Scan.
+func (m *BigMoney) Scan(src any) error {
+	amount, err := sqlScan(src)
+	if err != nil {
+		return err
+	}
+
+	var unit *big.Int
+	if m.unit != nil {
+		unit = m.Unit()
+	}
+
+	res := BigMoney{
+		amount:   amount,
+		unit:     defaultUnit(unit, m.currency),
+		currency: m.currency,
+		signed:   m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// ScanColumns is like Money.ScanColumns.
+func (m *BigMoney) ScanColumns() (amount, currency sql.Scanner) {
+	c := &columns{m: m}
+	return &amountColumn{c}, &currencyColumn{c}
+}
+
+// ValueColumns is like Money.ValueColumns.
+func (m *BigMoney) ValueColumns() (amount, currency driver.Valuer) {
+	return m, m.currency
+}
+
+// Value implements driver.Valuer, storing the code.
+func (c Currency) Value() (driver.Value, error) {
+	return c.Code, nil
+}
+
+// Scan implements sql.Scanner, looking up the registered currency for the
+// code.
+func (c *Currency) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return c.UnmarshalText([]byte(strings.TrimSpace(v)))
+	case []byte:
+		return c.UnmarshalText([]byte(strings.TrimSpace(string(v))))
+	default:
+		return fmt.Errorf("money: cannot scan %T into Currency", src)
+	}
+}
+
+// columns scans the amount and the currency into Money or BigMoney, in
+// either order, and sets both once the second is scanned.
+type columns struct {
+	m interface {
+		scanColumns(amount *big.Int, c Currency) error
+	}
+
+	amount   *big.Int
+	currency *Currency
+}
+
+func (c *columns) scanned() error {
+	if c.amount == nil || c.currency == nil {
+		return nil
+	}
+
+	amount, currency := c.amount, *c.currency
+	c.amount, c.currency = nil, nil
+
+	return c.m.scanColumns(amount, currency)
+}
+
+// amountColumn scans the amount of columns.
+type amountColumn struct {
+	c *columns
+}
+
+func (a *amountColumn) Scan(src any) error {
+	n, err := sqlScan(src)
+	if err != nil {
+		return err
+	}
+
+	a.c.amount = n
+
+	return a.c.scanned()
+}
+
+// currencyColumn scans the currency of columns.
+type currencyColumn struct {
+	c *columns
+}
+
+func (cc *currencyColumn) Scan(src any) error {
+	var cur Currency
+	if err := cur.Scan(src); err != nil {
+		return err
+	}
+
+	cc.c.currency = &cur
+
+	return cc.c.scanned()
+}
+
+func (m *Money[T]) scanColumns(n *big.Int, c Currency) error {
+	amount, ok := integerFromBigInt[T](n)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOverflow, n)
+	}
+
+	unit, currency := columnUnit(bigIntFromInteger(m.unit), m.currency, c)
+	u, ok := integerFromBigInt[T](unit)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOverflow, unit)
+	}
+
+	res := Money[T]{
+		amount:   amount,
+		unit:     u,
+		currency: currency,
+		signed:   m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+func (m *BigMoney) scanColumns(n *big.Int, c Currency) error {
+	unit, currency := columnUnit(m.unit, m.currency, c)
+	res := BigMoney{
+		amount:   n,
+		unit:     unit,
+		currency: currency,
+		signed:   m.signed,
+	}
+	if err := res.Validate(); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// columnUnit returns the unit and currency for the scanned currency. The
+// unit and currency of the receiver, e.g. SGD with a unit of 5, are kept if
+// the receiver has no currency or the same, and otherwise the scanned
+// currency's unit applies.
+func columnUnit(unit *big.Int, cur, scanned Currency) (*big.Int, Currency) {
+	switch {
+	case cur.Equal(scanned):
+		return defaultUnit(unit, cur), cur
+	case cur.IsZero():
+		return defaultUnit(unit, scanned), scanned
+	default:
+		return defaultUnit(nil, scanned), scanned
+	}
+}
+
+func sqlValue(n *big.Int) driver.Value {
+	if n.IsInt64() {
+		return n.Int64()
+	}
+
+	return n.String()
+}
+
+func sqlScan(src any) (*big.Int, error) {
+	var s string
+	switch v := src.(type) {
+	case int64:
+		return big.NewInt(v), nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return nil, fmt.Errorf("money: cannot scan %T into money", src)
+	}
+
+	// NUMERIC columns may return a zero scale, e.g. 5030.00.
+	s = strings.TrimSpace(s)
+	if whole, frac, ok := strings.Cut(s, "."); ok && strings.Trim(frac, "0") == "" {
+		s = whole
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,119 @@
+package money_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneySQL(t *testing.T) {
+	t.Run("value", func(t *testing.T) {
+		assert := assert.New(t)
+
+		v, err := money.USD.New(50, 30).Value()
+		assert.Nil(err)
+		assert.Equal(driver.Value(int64(5030)), v)
+
+		v, err = money.NewMoney(uint64(1<<63), 1).Value()
+		assert.Nil(err)
+		assert.Equal(driver.Value("9223372036854775808"), v)
+
+		_, err = money.NewMoney(3, 2).Value()
+		assert.True(errors.Is(err, money.ErrFractionalAmount))
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoneyWithCurrency(int64(0), money.SGD.WithUnit(5))
+		for _, src := range []any{int64(5030), "5030", []byte("5030.00")} {
+			assert.Nil(m.Scan(src))
+			assert.Equal(int64(5030), m.Amount())
+			assert.Equal(int64(5), m.Unit())
+			assert.Equal("SGD", m.Currency().Code)
+		}
+	})
+
+	t.Run("scan validates", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.NewMoney[int64](0, 5)
+		assert.True(errors.Is(m.Scan(int64(5032)), money.ErrFractionalAmount))
+		assert.True(errors.Is(m.Scan("50.32"), money.ErrSyntax))
+		assert.NotNil(m.Scan(50.3))
+		assert.NotNil(m.Scan(nil))
+
+		small := money.NewMoney[int8](0, 1)
+		assert.True(errors.Is(small.Scan(int64(1000)), money.ErrOverflow))
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		assert := assert.New(t)
+
+		amount, currency := money.IDR.New(534_000, 0).ValueColumns()
+		av, err := amount.Value()
+		assert.Nil(err)
+		cv, err := currency.Value()
+		assert.Nil(err)
+		assert.Equal(driver.Value(int64(534000)), av)
+		assert.Equal(driver.Value("IDR"), cv)
+
+		var m money.Money[int64]
+		as, cs := m.ScanColumns()
+		assert.Nil(as.Scan(av))
+		assert.Nil(cs.Scan([]byte("IDR")))
+		assert.True(money.IDR.New(534_000, 0).Equal(&m))
+
+		assert.True(errors.Is(cs.Scan("XYZ"), money.ErrUnknownCurrency))
+	})
+
+	t.Run("columns validate against the unit", func(t *testing.T) {
+		assert := assert.New(t)
+
+		m := money.SGD.WithUnit(5).New(0, 0)
+		as, cs := m.ScanColumns()
+		assert.Nil(as.Scan(int64(5030)))
+		assert.Nil(cs.Scan("SGD"))
+		assert.Equal(int64(5), m.Unit())
+		assert.Equal(int64(5), m.Currency().Unit)
+
+		assert.Nil(as.Scan(int64(5032)))
+		assert.True(errors.Is(cs.Scan("SGD"), money.ErrFractionalAmount))
+		assert.Equal(int64(5030), m.Amount())
+
+		// The currency may come first, and a different currency takes its
+		// own unit.
+		assert.Nil(cs.Scan("USD"))
+		assert.Nil(as.Scan(int64(5032)))
+		assert.Equal(int64(1), m.Unit())
+		assert.Equal(money.USD, m.Currency())
+
+		assert.Nil(cs.Scan("IDR"))
+		assert.Nil(as.Scan(int64(534_000)))
+		assert.True(money.IDR.New(534_000, 0).Equal(m))
+	})
+}
+
+func TestBigMoneySQL(t *testing.T) {
+	assert := assert.New(t)
+
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	v, err := money.NewBigMoneyWithCurrency(amount, money.USD).Value()
+	assert.Nil(err)
+	assert.Equal(driver.Value("123456789012345678901234567890"), v)
+
+	var m money.BigMoney
+	as, cs := m.ScanColumns()
+	assert.Nil(as.Scan([]byte("123456789012345678901234567890")))
+	assert.Nil(cs.Scan("USD"))
+	assert.Equal(amount, m.Amount())
+	assert.Equal(int64(1), m.Unit().Int64())
+	assert.Equal(money.USD, m.Currency())
+
+	m = *money.NewBigMoney(big.NewInt(0), big.NewInt(5))
+	assert.True(errors.Is(m.Scan(int64(3)), money.ErrFractionalAmount))
+}
@@ -0,0 +1,110 @@
+package money
+
+import (
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+// TaxBreakdown splits a price into the net amount and the tax, where Net +
+// Tax equals Gross exactly.
+type TaxBreakdown[T constraints.Integer] struct {
+	Net   *Money[T]
+	Tax   *Money[T]
+	Gross *Money[T]
+}
+
+// BigTaxBreakdown is like TaxBreakdown, but for BigMoney.
+type BigTaxBreakdown struct {
+	Net   *BigMoney
+	Tax   *BigMoney
+	Gross *BigMoney
+}
+
+// TaxExclusive adds the tax on top of the net amount, e.g. a price before
+// GST. The tax is rounded half up to the unit unless WithRounding is given.
+func TaxExclusive[T constraints.Integer](m *Money[T], rate Percenter, opts ...Option) (*TaxBreakdown[T], error) {
+	return tax(m, rate, false, opts)
+}
+
+// TaxInclusive extracts the tax from the gross amount, e.g. a GST-inclusive
+// price. The tax is rounded half up to the unit unless WithRounding is given,
+// and the net amount is the rest.
+func TaxInclusive[T constraints.Integer](m *Money[T], rate Percenter, opts ...Option) (*TaxBreakdown[T], error) {
+	return tax(m, rate, true, opts)
+}
+
+// TaxExclusiveBig is like TaxExclusive, but for BigMoney.
+func TaxExclusiveBig(m *BigMoney, rate Percenter, opts ...Option) (*BigTaxBreakdown, error) {
+	return taxBig(m, rate, false, opts)
+}
+
+// TaxInclusiveBig is like TaxInclusive, but for BigMoney.
+func TaxInclusiveBig(m *BigMoney, rate Percenter, opts ...Option) (*BigTaxBreakdown, error) {
+	return taxBig(m, rate, true, opts)
+}
+
+func tax[T constraints.Integer](m *Money[T], rate Percenter, inclusive bool, opts []Option) (*TaxBreakdown[T], error) {
+	b, err := taxBig(m.Big(), rate, inclusive, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	net, err := MoneyFromBig[T](b.Net)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := MoneyFromBig[T](b.Tax)
+	if err != nil {
+		return nil, err
+	}
+
+	gross, err := MoneyFromBig[T](b.Gross)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaxBreakdown[T]{Net: net, Tax: t, Gross: gross}, nil
+}
+
+func taxBig(m *BigMoney, rate Percenter, inclusive bool, opts []Option) (*BigTaxBreakdown, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := rate.Percentage()
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundHalfUp, optRounding, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tax on a gross amount is gross * rate / (1 + rate).
+	r := p.Rat()
+	if inclusive {
+		r.Quo(r, new(big.Rat).Add(r, big.NewRat(1, 1)))
+	}
+
+	t := m.WithAmount(quantize(m.amount, r, m.unit, o.rounding))
+	if inclusive {
+		net, _ := m.Sub(t)
+		return &BigTaxBreakdown{Net: net, Tax: t, Gross: m.WithAmount(m.Amount())}, nil
+	}
+
+	gross, _ := m.Add(t)
+
+	return &BigTaxBreakdown{Net: m.WithAmount(m.Amount()), Tax: t, Gross: gross}, nil
+}
+
+// quantize returns amount * r, rounded to a multiple of unit. Negative
+// amounts round like their positive mirror.
+func quantize(amount *big.Int, r *big.Rat, unit *big.Int, mode RoundingMode) *big.Int {
+	x := new(big.Rat).SetFrac(amount, unit)
+	x.Mul(x, r)
+
+	return mulBigInt(roundAbs(x, mode), unit)
+}
@@ -0,0 +1,287 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrNoLines         = errors.New("money: no line items")
+	ErrTaxScopeInvalid = errors.New("money: invalid tax scope")
+)
+
+// TaxScope decides whether a tax is rounded on each line, or once on the
+// invoice.
+type TaxScope int
+
+const (
+	// PerLine rounds the tax on each line, and the invoice tax is the sum of
+	// the lines.
+	PerLine TaxScope = iota
+
+	// PerInvoice rounds the tax once on the invoice total, and attributes it
+	// back to the lines by their taxable amounts, see LargestRemainder.
+	PerInvoice
+)
+
+var taxScopeNames = map[TaxScope]string{
+	PerLine:    "per-line",
+	PerInvoice: "per-invoice",
+}
+
+func (s TaxScope) String() string {
+	if name, ok := taxScopeNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("TaxScope(%d)", int(s))
+}
+
+// Validate checks if the scope is known.
+func (s TaxScope) Validate() error {
+	if _, ok := taxScopeNames[s]; !ok {
+		return fmt.Errorf("%w: %d", ErrTaxScopeInvalid, int(s))
+	}
+
+	return nil
+}
+
+// Tax is a single tax in a TaxStack.
+type Tax struct {
+	Name string
+	Rate Percenter
+
+	// Compound taxes are charged on the net amount plus the taxes before
+	// it, e.g. the former Quebec QST on top of GST. Otherwise the tax is
+	// charged on the net amount only.
+	Compound bool
+
+	Scope TaxScope
+}
+
+// TaxStack is a list of taxes applied in order, e.g. GST and PST, or state,
+// county and city sales taxes.
+type TaxStack []Tax
+
+// Validate checks the rate and scope of every tax.
+func (s TaxStack) Validate() error {
+	for _, t := range s {
+		if t.Rate == nil {
+			return fmt.Errorf("%s: %w: no rate", t.Name, ErrPercentOutOfRange)
+		}
+
+		if err := t.Rate.Percentage().Validate(); err != nil {
+			return fmt.Errorf("%s: %w", t.Name, err)
+		}
+
+		if err := t.Scope.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// TaxItem is the amount charged for a single tax.
+type TaxItem[T constraints.Integer] struct {
+	Tax Tax
+
+	// Base is the taxable amount.
+	Base *Money[T]
+
+	// Amount is the tax, and the sum of Lines.
+	Amount *Money[T]
+
+	// Lines is the tax attributed to each line.
+	Lines []*Money[T]
+}
+
+// TaxInvoice is the itemised tax of a list of lines, where Net + Tax equals
+// Gross exactly, and the items sum up to Tax.
+type TaxInvoice[T constraints.Integer] struct {
+	Net   *Money[T]
+	Items []TaxItem[T]
+	Tax   *Money[T]
+	Gross *Money[T]
+}
+
+// BigTaxItem is like TaxItem, but for BigMoney.
+type BigTaxItem struct {
+	Tax    Tax
+	Base   *BigMoney
+	Amount *BigMoney
+	Lines  []*BigMoney
+}
+
+// BigTaxInvoice is like TaxInvoice, but for BigMoney.
+type BigTaxInvoice struct {
+	Net   *BigMoney
+	Items []BigTaxItem
+	Tax   *BigMoney
+	Gross *BigMoney
+}
+
+// ApplyTaxes applies the taxes in order on the net amounts of the lines. The
+// lines must share the same unit and currency. Each tax is rounded half up to
+// the unit unless WithRounding is given.
+func ApplyTaxes[T constraints.Integer](lines []*Money[T], s TaxStack, opts ...Option) (*TaxInvoice[T], error) {
+	bigLines := make([]*BigMoney, len(lines))
+	for i, l := range lines {
+		bigLines[i] = l.Big()
+	}
+
+	b, err := ApplyTaxesBig(bigLines, s, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TaxInvoice[T]{Items: make([]TaxItem[T], len(b.Items))}
+	for i, item := range b.Items {
+		res.Items[i].Tax = item.Tax
+		if res.Items[i].Base, err = MoneyFromBig[T](item.Base); err != nil {
+			return nil, err
+		}
+
+		if res.Items[i].Amount, err = MoneyFromBig[T](item.Amount); err != nil {
+			return nil, err
+		}
+
+		res.Items[i].Lines = make([]*Money[T], len(item.Lines))
+		for j, l := range item.Lines {
+			if res.Items[i].Lines[j], err = MoneyFromBig[T](l); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if res.Net, err = MoneyFromBig[T](b.Net); err != nil {
+		return nil, err
+	}
+
+	if res.Tax, err = MoneyFromBig[T](b.Tax); err != nil {
+		return nil, err
+	}
+
+	if res.Gross, err = MoneyFromBig[T](b.Gross); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ApplyTaxesBig is like ApplyTaxes, but for BigMoney.
+func ApplyTaxesBig(lines []*BigMoney, s TaxStack, opts ...Option) (*BigTaxInvoice, error) {
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	for _, l := range lines {
+		if err := l.Validate(); err != nil {
+			return nil, err
+		}
+
+		if l.amount.Sign() < 0 {
+			return nil, ErrNegativeAmount
+		}
+
+		if err := lines[0].compatible(l); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := newOptions(RoundHalfUp, optRounding, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m := lines[0]
+	unit := m.unit
+
+	// taxed is the tax charged so far on each line, for compound taxes.
+	taxed := make([]*big.Int, len(lines))
+	for i := range taxed {
+		taxed[i] = new(big.Int)
+	}
+
+	net := new(big.Int)
+	for _, l := range lines {
+		net.Add(net, l.amount)
+	}
+
+	total := new(big.Int)
+	items := make([]BigTaxItem, len(s))
+	for i, t := range s {
+		bases := make([]*big.Int, len(lines))
+		base := new(big.Int)
+		for j, l := range lines {
+			bases[j] = new(big.Int).Set(l.amount)
+			if t.Compound {
+				bases[j].Add(bases[j], taxed[j])
+			}
+			base.Add(base, bases[j])
+		}
+
+		r := t.Rate.Percentage().Rat()
+		var amounts []*big.Int
+		switch t.Scope {
+		case PerInvoice:
+			amounts = attribute(quantize(base, r, unit, o.rounding), unit, bases)
+		default:
+			amounts = make([]*big.Int, len(bases))
+			for j, b := range bases {
+				amounts[j] = quantize(b, r, unit, o.rounding)
+			}
+		}
+
+		amount := SumBig(amounts)
+		for j, a := range amounts {
+			taxed[j].Add(taxed[j], a)
+		}
+		total.Add(total, amount)
+
+		items[i] = BigTaxItem{
+			Tax:    t,
+			Base:   m.WithAmount(base),
+			Amount: m.WithAmount(amount),
+			Lines:  m.WithAmounts(amounts),
+		}
+	}
+
+	return &BigTaxInvoice{
+		Net:   m.WithAmount(net),
+		Items: items,
+		Tax:   m.WithAmount(total),
+		Gross: m.WithAmount(new(big.Int).Add(net, total)),
+	}, nil
+}
+
+// attribute splits the amount by the weights with LargestRemainder, in
+// multiples of the unit. The amount and weights are multiples of the unit.
+func attribute(amount, unit *big.Int, weights []*big.Int) []*big.Int {
+	units := make([]*big.Int, len(weights))
+	for i, w := range weights {
+		units[i] = new(big.Int).Quo(w, unit)
+	}
+
+	res := make([]*big.Int, len(weights))
+	if SumBig(units).Sign() == 0 {
+		for i := range res {
+			res[i] = new(big.Int)
+		}
+
+		return res
+	}
+
+	for i, u := range largestRemainder(new(big.Int).Quo(amount, unit), units) {
+		res[i] = mulBigInt(u, unit)
+	}
+
+	return res
+}
@@ -0,0 +1,166 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTaxes(t *testing.T) {
+	gst := money.Tax{Name: "GST", Rate: money.NewPercentage(5, 1)}
+	pst := money.Tax{Name: "PST", Rate: money.NewPercentage(7, 1)}
+	qst := money.Tax{Name: "QST", Rate: money.NewPercentage(9975, 1000), Compound: true}
+
+	perInvoice := func(t money.Tax) money.Tax {
+		t.Scope = money.PerInvoice
+		return t
+	}
+
+	tests := []struct {
+		lines    []int64
+		stack    money.TaxStack
+		items    [][]int64
+		scenario string
+	}{
+		{
+			lines:    []int64{1000, 555},
+			stack:    money.TaxStack{gst, pst},
+			items:    [][]int64{{50, 28}, {70, 39}},
+			scenario: "additive per line",
+		},
+		{
+			lines:    []int64{1000, 555},
+			stack:    money.TaxStack{perInvoice(gst), perInvoice(pst)},
+			items:    [][]int64{{50, 28}, {70, 39}},
+			scenario: "additive per invoice",
+		},
+		{
+			lines:    []int64{333, 333, 333},
+			stack:    money.TaxStack{gst},
+			items:    [][]int64{{17, 17, 17}},
+			scenario: "per line rounds each line",
+		},
+		{
+			lines:    []int64{333, 333, 333},
+			stack:    money.TaxStack{perInvoice(gst)},
+			items:    [][]int64{{17, 17, 16}},
+			scenario: "per invoice rounds once",
+		},
+		{
+			lines:    []int64{10000},
+			stack:    money.TaxStack{gst, qst},
+			items:    [][]int64{{500}, {1047}},
+			scenario: "compound on the taxes before",
+		},
+		{
+			lines: []int64{1999},
+			stack: money.TaxStack{
+				{Name: "state", Rate: money.NewPercentage(625, 100), Scope: money.PerInvoice},
+				{Name: "county", Rate: money.NewPercentage(1, 1), Scope: money.PerInvoice},
+				{Name: "city", Rate: money.NewPercentage(1, 1), Scope: money.PerInvoice},
+			},
+			items:    [][]int64{{125}, {20}, {20}},
+			scenario: "state, county and city",
+		},
+		{
+			lines:    []int64{0, 0},
+			stack:    money.TaxStack{perInvoice(gst)},
+			items:    [][]int64{{0, 0}},
+			scenario: "nothing to tax",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			lines := make([]*money.Money[int64], len(test.lines))
+			for i, l := range test.lines {
+				lines[i] = money.NewMoneyWithCurrency(l, money.CAD)
+			}
+
+			inv, err := money.ApplyTaxes(lines, test.stack)
+			assert.Nil(err)
+			assert.Len(inv.Items, len(test.items))
+
+			var net, tax int64
+			for _, l := range test.lines {
+				net += l
+			}
+
+			for i, item := range inv.Items {
+				var amount int64
+				for j, l := range item.Lines {
+					assert.Equal(test.items[i][j], l.Amount(), "%s line %d", item.Tax.Name, j)
+					amount += l.Amount()
+				}
+				assert.Equal(amount, item.Amount.Amount())
+				tax += amount
+			}
+
+			assert.Equal(net, inv.Net.Amount())
+			assert.Equal(tax, inv.Tax.Amount())
+			assert.Equal(net+tax, inv.Gross.Amount())
+			assert.Equal(money.CAD, inv.Gross.Currency())
+		})
+	}
+}
+
+func TestApplyTaxesUnit(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []*money.Money[int64]{money.NewMoney[int64](1005, 5), money.NewMoney[int64](2000, 5)}
+	stack := money.TaxStack{{Name: "GST", Rate: money.NewPercentage(9, 1), Scope: money.PerInvoice}}
+
+	inv, err := money.ApplyTaxes(lines, stack)
+	assert.Nil(err)
+	assert.Equal(int64(270), inv.Tax.Amount())
+	for _, l := range inv.Items[0].Lines {
+		assert.Equal(int64(0), l.Amount()%5)
+	}
+	assert.Equal(int64(3275), inv.Gross.Amount())
+}
+
+func TestApplyTaxesBig(t *testing.T) {
+	assert := assert.New(t)
+
+	n, _ := new(big.Int).SetString("100000000000000000000", 10)
+	lines := []*money.BigMoney{money.NewBigMoneyWithCurrency(n, money.USD)}
+	stack := money.TaxStack{{Name: "VAT", Rate: money.NewPercentage(20, 1)}}
+
+	inv, err := money.ApplyTaxesBig(lines, stack)
+	assert.Nil(err)
+	assert.Equal("20000000000000000000", inv.Tax.Amount().String())
+	assert.Equal("120000000000000000000", inv.Gross.Amount().String())
+}
+
+func TestApplyTaxesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	gst := money.TaxStack{{Name: "GST", Rate: money.NewPercentage(5, 1)}}
+
+	_, err := money.ApplyTaxes[int64](nil, gst)
+	assert.True(errors.Is(err, money.ErrNoLines))
+
+	_, err = money.ApplyTaxes([]*money.Money[int64]{
+		money.NewMoneyWithCurrency[int64](100, money.CAD),
+		money.NewMoneyWithCurrency[int64](100, money.USD),
+	}, gst)
+	assert.True(errors.Is(err, money.ErrCurrencyMismatch))
+
+	_, err = money.ApplyTaxes([]*money.Money[int64]{money.NewMoney[int64](100, 1)}, money.TaxStack{
+		{Name: "bad", Rate: money.NewPercentage(120, 1)},
+	})
+	assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+
+	_, err = money.ApplyTaxes([]*money.Money[int64]{money.NewMoney[int64](100, 1)}, money.TaxStack{
+		{Name: "bad", Rate: money.NewPercentage(5, 1), Scope: money.TaxScope(9)},
+	})
+	assert.True(errors.Is(err, money.ErrTaxScopeInvalid))
+
+	_, err = money.ApplyTaxes([]*money.Money[int8]{money.NewMoney[int8](100, 1), money.NewMoney[int8](27, 1)}, gst)
+	assert.True(errors.Is(err, money.ErrOverflow))
+}
@@ -0,0 +1,119 @@
+package money_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/alextanhongpin/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxExclusive(t *testing.T) {
+	tests := []struct {
+		net      int64
+		rate     string
+		tax      int64
+		scenario string
+	}{
+		{net: 10000, rate: "8.875%", tax: 888, scenario: "fractional rate rounds half up"},
+		{net: 1000, rate: "9%", tax: 90, scenario: "SG GST"},
+		{net: 1999, rate: "6%", tax: 120, scenario: "MY SST"},
+		{net: 1000, rate: "0%", tax: 0, scenario: "zero rated"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			rate, err := money.ParsePercentage(test.rate)
+			assert.Nil(err)
+
+			res, err := money.TaxExclusive(money.NewMoneyWithCurrency(test.net, money.USD), rate)
+			assert.Nil(err)
+			assert.Equal(test.net, res.Net.Amount())
+			assert.Equal(test.tax, res.Tax.Amount())
+			assert.Equal(test.net+test.tax, res.Gross.Amount())
+			assert.Equal(money.USD, res.Gross.Currency())
+
+			big, err := money.TaxExclusiveBig(money.NewBigMoneyWithCurrency(big.NewInt(test.net), money.USD), rate)
+			assert.Nil(err)
+			assert.Equal(test.tax, big.Tax.Amount().Int64())
+			assert.Equal(test.net+test.tax, big.Gross.Amount().Int64())
+		})
+	}
+}
+
+func TestTaxInclusive(t *testing.T) {
+	tests := []struct {
+		gross    int64
+		unit     int64
+		rate     money.Percentage
+		tax      int64
+		scenario string
+	}{
+		{gross: 1090, unit: 1, rate: money.NewPercentage(9, 1), tax: 90, scenario: "SG GST"},
+		{gross: 1000, unit: 1, rate: money.NewPercentage(8875, 1000), tax: 82, scenario: "fractional rate"},
+		{gross: 1000, unit: 5, rate: money.NewPercentage(9, 1), tax: 85, scenario: "unit 5"},
+		{gross: 1, unit: 1, rate: money.NewPercentage(6, 1), tax: 0, scenario: "too small to tax"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := money.NewMoney(test.gross, test.unit)
+			res, err := money.TaxInclusive(m, test.rate)
+			assert.Nil(err)
+			assert.Equal(test.tax, res.Tax.Amount())
+			assert.Equal(test.gross-test.tax, res.Net.Amount())
+			assert.Equal(test.gross, res.Gross.Amount())
+			assert.Equal(test.unit, res.Tax.Unit())
+
+			sum, err := res.Net.Add(res.Tax)
+			assert.Nil(err)
+			assert.True(sum.Equal(res.Gross))
+
+			b, err := money.TaxInclusiveBig(money.NewBigMoney(big.NewInt(test.gross), big.NewInt(test.unit)), test.rate)
+			assert.Nil(err)
+			assert.Equal(test.tax, b.Tax.Amount().Int64())
+			assert.Equal(test.gross-test.tax, b.Net.Amount().Int64())
+		})
+	}
+}
+
+func TestTaxErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := money.TaxExclusive(money.NewMoney(100, 1), money.NewPercentage(101, 1))
+	assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+
+	_, err = money.TaxInclusive(money.NewMoney(3, 2), money.NewPercentage(9, 1))
+	assert.True(errors.Is(err, money.ErrFractionalAmount))
+
+	_, err = money.TaxExclusive(money.NewMoney(int8(100), 1), money.NewPercentage(50, 1))
+	assert.True(errors.Is(err, money.ErrOverflow))
+
+	res, err := money.TaxExclusive(money.NewMoney(1000, 1), money.NewPercentage(8875, 1000), money.WithRounding(money.RoundDown))
+	assert.Nil(err)
+	assert.Equal(88, res.Tax.Amount())
+}
+
+func TestTaxPercent(t *testing.T) {
+	assert := assert.New(t)
+
+	res, err := money.TaxExclusive(money.NewMoney(1999, 1), money.Percent(6))
+	assert.Nil(err)
+	assert.Equal(120, res.Tax.Amount())
+
+	b, err := money.TaxInclusiveBig(money.NewBigMoney(big.NewInt(1090), big.NewInt(1)), money.Percent(9))
+	assert.Nil(err)
+	assert.Equal(int64(90), b.Tax.Amount().Int64())
+
+	inv, err := money.ApplyTaxes([]*money.Money[int64]{money.NewMoney[int64](1000, 1)}, money.TaxStack{{Name: "GST", Rate: money.Percent(9)}})
+	assert.Nil(err)
+	assert.Equal(int64(90), inv.Tax.Amount())
+
+	_, err = money.ApplyTaxes([]*money.Money[int64]{money.NewMoney[int64](1000, 1)}, money.TaxStack{{Name: "GST"}})
+	assert.True(errors.Is(err, money.ErrPercentOutOfRange))
+}